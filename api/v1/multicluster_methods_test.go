@@ -69,4 +69,29 @@ var _ = Describe("V1 API Methods", func() {
 			Expect(m.Prune("test")).To(BeFalse())
 		})
 	})
+
+	Context("when an uninstall policy is set", func() {
+		It("retains CRDs by default", func() {
+			m := makeMCE()
+			Expect(m.ShouldDeleteCRDs()).To(BeFalse())
+			m.Spec.UninstallPolicy = &api.UninstallPolicy{CRDs: api.UninstallPolicyRetain}
+			Expect(m.ShouldDeleteCRDs()).To(BeFalse())
+		})
+
+		It("deletes CRDs when requested", func() {
+			m := makeMCE()
+			m.Spec.UninstallPolicy = &api.UninstallPolicy{CRDs: api.UninstallPolicyDelete}
+			Expect(m.ShouldDeleteCRDs()).To(BeTrue())
+		})
+	})
+
+	Context("when pull secrets are set", func() {
+		It("combines imagePullSecret and imagePullSecrets without duplicates", func() {
+			m := makeMCE()
+			Expect(m.PullSecrets()).To(BeEmpty())
+			m.Spec.ImagePullSecret = "pull-secret"
+			m.Spec.ImagePullSecrets = []string{"mirror-secret", "pull-secret", ""}
+			Expect(m.PullSecrets()).To(Equal([]string{"pull-secret", "mirror-secret"}))
+		})
+	})
 })
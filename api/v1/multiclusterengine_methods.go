@@ -149,3 +149,33 @@ func IsInHostedMode(mce *MultiClusterEngine) bool {
 	}
 	return false
 }
+
+// ShouldDeleteCRDs returns true if the CRDs installed by the operator should be removed when the
+// MultiClusterEngine is deleted
+func (mce *MultiClusterEngine) ShouldDeleteCRDs() bool {
+	if mce.Spec.UninstallPolicy == nil {
+		return false
+	}
+	return mce.Spec.UninstallPolicy.CRDs == UninstallPolicyDelete
+}
+
+// PullSecrets returns the names of all image pull secrets set in the spec, without duplicates
+func (mce *MultiClusterEngine) PullSecrets() []string {
+	secrets := []string{}
+	for _, s := range append([]string{mce.Spec.ImagePullSecret}, mce.Spec.ImagePullSecrets...) {
+		if s == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range secrets {
+			if existing == s {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
+}
@@ -29,6 +29,9 @@ type AvailabilityType string
 // DeploymentMode
 type DeploymentMode string
 
+// UninstallPolicyType determines what happens to a resource when the MultiClusterEngine is deleted
+type UninstallPolicyType string
+
 const (
 	// HABasic stands up most app subscriptions with a replicaCount of 1
 	HABasic AvailabilityType = "Basic"
@@ -38,6 +41,10 @@ const (
 	ModeHosted DeploymentMode = "Hosted"
 	// ModeStandalone deployos the MCE in the default manner
 	ModeStandalone DeploymentMode = "Standalone"
+	// UninstallPolicyRetain leaves the resource in place when the MultiClusterEngine is deleted
+	UninstallPolicyRetain UninstallPolicyType = "Retain"
+	// UninstallPolicyDelete removes the resource when the MultiClusterEngine is deleted
+	UninstallPolicyDelete UninstallPolicyType = "Delete"
 )
 
 // MultiClusterEngineSpec defines the desired state of MultiClusterEngine
@@ -54,6 +61,11 @@ type MultiClusterEngineSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secret",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ImagePullSecret string `json:"imagePullSecret,omitempty"`
 
+	// Additional pull secrets for accessing MultiClusterEngine operand and endpoint images. Each secret must exist in
+	// the target namespace and is copied to every namespace components are deployed into.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
 	// Developer Overrides
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Developer Overrides",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
 	Overrides *Overrides `json:"overrides,omitempty"`
@@ -64,6 +76,82 @@ type MultiClusterEngineSpec struct {
 	// Location where MCE resources will be placed
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Namespace",xDescriptors={"urn:alm:descriptor:io.kubernetes:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// Determines which resources are removed when the MultiClusterEngine is deleted
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Uninstall Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	UninstallPolicy *UninstallPolicy `json:"uninstallPolicy,omitempty"`
+
+	// Configures the monitoring deployed for the operator's own metrics
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Monitoring",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// Determines how long components may be unavailable before the MultiClusterEngineFailure condition is set
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Readiness Deadlines",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ReadinessDeadlines *ReadinessDeadlines `json:"readinessDeadlines,omitempty"`
+}
+
+// ReadinessDeadlines configures how long the components in status.components may be unavailable
+type ReadinessDeadlines struct {
+	// Default is how long a component may be unavailable when it has no deadline of its own. Defaults to 20m.
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+
+	// Components overrides the default deadline of individual components
+	// +optional
+	Components []ComponentDeadline `json:"components,omitempty"`
+}
+
+// ComponentDeadline overrides the readiness deadline of a component
+type ComponentDeadline struct {
+	// Name of the component as reported in status.components
+	Name string `json:"name"`
+
+	// Kind of the component as reported in status.components. Matches components of any kind when empty.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Deadline is how long the component may be unavailable
+	Deadline metav1.Duration `json:"deadline"`
+}
+
+// Monitoring configures the ServiceMonitor and PrometheusRule deployed for the operator's metrics
+type Monitoring struct {
+	// Enabled deploys a ServiceMonitor for the operator's metrics and a PrometheusRule with alerts into
+	// the target namespace when the monitoring CRDs are present
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ComponentUnavailableFor is how long a component must be unavailable before alerting. Defaults to 10m.
+	// +optional
+	ComponentUnavailableFor *metav1.Duration `json:"componentUnavailableFor,omitempty"`
+
+	// ReconcileErrorsThreshold is the number of errors applying or deleting the resources of a component
+	// within 15 minutes above which to alert. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ReconcileErrorsThreshold *int32 `json:"reconcileErrorsThreshold,omitempty"`
+
+	// PhaseErrorFor is how long the MultiClusterEngine must be in the Error phase before alerting. Defaults to 5m.
+	// +optional
+	PhaseErrorFor *metav1.Duration `json:"phaseErrorFor,omitempty"`
+
+	// UpgradeStuckFor is how long an upgrade may take before alerting. Defaults to 1h.
+	// +optional
+	UpgradeStuckFor *metav1.Duration `json:"upgradeStuckFor,omitempty"`
+}
+
+// UninstallPolicy configures the removal of resources when the MultiClusterEngine is deleted
+type UninstallPolicy struct {
+	// CRDs determines whether the CustomResourceDefinitions of components are retained or deleted. When
+	// set to Delete, each CRD is removed once no custom resources of its type remain. The ClusterManager
+	// and HiveConfig CRDs the operator watches are always retained.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +kubebuilder:default=Retain
+	// +optional
+	CRDs UninstallPolicyType `json:"crds,omitempty"`
 }
 
 // ComponentConfig provides optional configuration items for individual components
@@ -113,7 +201,7 @@ type ComponentCondition struct {
 	Kind string `json:"kind,omitempty"`
 
 	// Available indicates whether this component is considered properly running
-	Available bool `json:"-"`
+	Available bool `json:"available,omitempty"`
 
 	// Type is the type of the cluster condition.
 	// +required
@@ -147,6 +235,12 @@ const (
 	MultiClusterEnginePhaseUninstalling  PhaseType = "Uninstalling"
 	MultiClusterEnginePhaseError         PhaseType = "Error"
 	MultiClusterEnginePhaseUnimplemented PhaseType = "Unimplemented"
+	// Degraded means the current version was installed but a component has since become unavailable
+	MultiClusterEnginePhaseDegraded PhaseType = "Degraded"
+	// Paused means reconciliation is paused by the pause annotation
+	MultiClusterEnginePhasePaused PhaseType = "Paused"
+	// Upgrading means components are rolling out a new version over an installed one
+	MultiClusterEnginePhaseUpgrading PhaseType = "Upgrading"
 )
 
 type MultiClusterEngineConditionType string
@@ -164,6 +258,18 @@ const (
 	// Failure is added in a deployment when one of its pods fails to be created
 	// or deleted.
 	MultiClusterEngineFailure MultiClusterEngineConditionType = "MultiClusterEngineFailure"
+	// ImagesMirrored reports whether every image was resolved to a cluster mirror. It is only
+	// present when image mirror resolution is enabled.
+	MultiClusterEngineImagesMirrored MultiClusterEngineConditionType = "ImagesMirrored"
+	// ImageOverridesValid reports whether every entry of the image override configmap was applied to
+	// a known image. It is only present when an image override configmap is referenced.
+	MultiClusterEngineImageOverridesValid MultiClusterEngineConditionType = "ImageOverridesValid"
+	// Degraded reports whether a component became unavailable after the current version was installed.
+	MultiClusterEngineDegraded MultiClusterEngineConditionType = "Degraded"
+	// Paused reports whether reconciliation is paused by the pause annotation.
+	MultiClusterEnginePaused MultiClusterEngineConditionType = "Paused"
+	// Upgrading reports whether components are rolling out a new version over an installed one.
+	MultiClusterEngineUpgrading MultiClusterEngineConditionType = "Upgrading"
 )
 
 type MultiClusterEngineCondition struct {
@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ValidateSpec checks the rules a MultiClusterEngine must follow that do not depend on the state of the
+// cluster
+func (r *MultiClusterEngine) ValidateSpec() error {
+	if (r.Spec.AvailabilityConfig != HABasic) && (r.Spec.AvailabilityConfig != HAHigh) && (r.Spec.AvailabilityConfig != "") {
+		return ErrInvalidAvailability
+	}
+
+	// Validate components
+	if r.Spec.Overrides != nil {
+		for _, c := range r.Spec.Overrides.Components {
+			if !validComponent(c) {
+				return fmt.Errorf("%w: %s is not a known component", ErrInvalidComponent, c.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSpecUpdate checks the rules an update from old must follow that do not depend on the state of
+// the cluster
+func (r *MultiClusterEngine) ValidateSpecUpdate(old *MultiClusterEngine) error {
+	if (r.Spec.TargetNamespace != old.Spec.TargetNamespace) && (old.Spec.TargetNamespace != "") {
+		return fmt.Errorf("%w: changes cannot be made to target namespace", ErrInvalidNamespace)
+	}
+	if IsInHostedMode(r) != IsInHostedMode(old) {
+		return fmt.Errorf("%w: changes cannot be made to DeploymentMode", ErrInvalidDeployMode)
+	}
+
+	oldNS, newNS := "", ""
+	if old.Spec.Overrides != nil {
+		oldNS = old.Spec.Overrides.InfrastructureCustomNamespace
+	}
+	if r.Spec.Overrides != nil {
+		newNS = r.Spec.Overrides.InfrastructureCustomNamespace
+	}
+	if oldNS != newNS {
+		return fmt.Errorf("%w: changes cannot be made to InfrastructureCustomNamespace", ErrInvalidInfraNS)
+	}
+
+	return r.ValidateSpec()
+}
+
+// ValidateWithExisting checks that a new MultiClusterEngine does not conflict with the existing
+// MultiClusterEngines. Only one may target a namespace and only one may run in Standalone mode.
+func (r *MultiClusterEngine) ValidateWithExisting(existing []MultiClusterEngine) error {
+	targetNS := r.Spec.TargetNamespace
+	if targetNS == "" {
+		targetNS = DefaultTargetNamespace
+	}
+
+	for _, mce := range existing {
+		mce := mce
+		if mce.Spec.TargetNamespace == targetNS || (targetNS == DefaultTargetNamespace && mce.Spec.TargetNamespace == "") {
+			return fmt.Errorf("%w: MultiClusterEngine with targetNamespace already exists: '%s'",
+				ErrInvalidNamespace, mce.Name)
+		}
+		if !IsInHostedMode(r) && !IsInHostedMode(&mce) {
+			return fmt.Errorf("%w: MultiClusterEngine in Standalone mode already exists: `%s`. "+
+				"Only one resource may exist in Standalone mode.", ErrInvalidDeployMode, mce.Name)
+		}
+	}
+	return nil
+}
+
+// disableBlockers are the resources that must be deleted before a component is disabled
+var disableBlockers = map[string][]blockingResource{
+	Discovery: {
+		{
+			Name: "DiscoveryConfig",
+			GVK: schema.GroupVersionKind{
+				Group:   "discovery.open-cluster-management.io",
+				Version: "v1",
+				Kind:    "DiscoveryConfigList",
+			},
+		},
+	},
+}
+
+// disabledComponentBlockers returns the resources that must not exist on the cluster because they
+// depend on a component r disables
+func (r *MultiClusterEngine) disabledComponentBlockers() []blockingResource {
+	blockers := []blockingResource{}
+	for _, component := range allComponents {
+		if r.ComponentPresent(component) && !r.Enabled(component) {
+			blockers = append(blockers, disableBlockers[component]...)
+		}
+	}
+	return blockers
+}
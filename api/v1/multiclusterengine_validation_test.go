@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package v1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/stolostron/backplane-operator/api/v1"
+)
+
+var _ = Describe("V1 API Validation", func() {
+	It("rejects unknown availability and components", func() {
+		mce := makeMCE(config("fake-component", true))
+		Expect(mce.ValidateSpec()).To(MatchError(api.ErrInvalidComponent))
+
+		mce = makeMCE()
+		mce.Spec.AvailabilityConfig = "Medium"
+		Expect(mce.ValidateSpec()).To(MatchError(api.ErrInvalidAvailability))
+	})
+
+	It("rejects changes to immutable fields", func() {
+		old := makeMCE()
+		old.Spec.TargetNamespace = api.DefaultTargetNamespace
+
+		mce := old.DeepCopy()
+		Expect(mce.ValidateSpecUpdate(old)).To(Succeed())
+
+		mce.Spec.TargetNamespace = "other"
+		Expect(mce.ValidateSpecUpdate(old)).To(MatchError(api.ErrInvalidNamespace))
+
+		mce = old.DeepCopy()
+		mce.SetAnnotations(map[string]string{"deploymentmode": string(api.ModeHosted)})
+		Expect(mce.ValidateSpecUpdate(old)).To(MatchError(api.ErrInvalidDeployMode))
+
+		mce = old.DeepCopy()
+		mce.Spec.Overrides = &api.Overrides{InfrastructureCustomNamespace: "infra"}
+		Expect(mce.ValidateSpecUpdate(old)).To(MatchError(api.ErrInvalidInfraNS))
+	})
+
+	It("rejects conflicts with existing MultiClusterEngines", func() {
+		existing := []api.MultiClusterEngine{{ObjectMeta: metav1.ObjectMeta{Name: "existing"}}}
+
+		mce := makeMCE()
+		Expect(mce.ValidateWithExisting(existing)).To(MatchError(api.ErrInvalidNamespace))
+
+		mce.Spec.TargetNamespace = "other"
+		Expect(mce.ValidateWithExisting(existing)).To(MatchError(api.ErrInvalidDeployMode))
+
+		mce.SetAnnotations(map[string]string{"deploymentmode": string(api.ModeHosted)})
+		Expect(mce.ValidateWithExisting(existing)).To(Succeed())
+		Expect(mce.ValidateWithExisting(nil)).To(Succeed())
+	})
+})
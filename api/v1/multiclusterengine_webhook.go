@@ -23,14 +23,13 @@ import (
 	"fmt"
 
 	admissionregistration "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
 	cl "sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -51,11 +50,7 @@ var (
 	ErrInvalidAvailability = errors.New("invalid AvailabilityConfig")
 	ErrInvalidInfraNS      = errors.New("invalid InfrastructureCustomNamespace")
 
-	blockDeletionResources = []struct {
-		Name       string
-		GVK        schema.GroupVersionKind
-		Exceptions []string
-	}{
+	blockDeletionResources = []blockingResource{
 		{
 			Name: "ManagedCluster",
 			GVK: schema.GroupVersionKind{
@@ -84,6 +79,14 @@ var (
 	}
 )
 
+// blockingResource is a kind of resource whose instances block a change to a MultiClusterEngine,
+// other than the named exceptions
+type blockingResource struct {
+	Name       string
+	GVK        schema.GroupVersionKind
+	Exceptions []string
+}
+
 // ValidatingWebhook returns the ValidatingWebhookConfiguration used for the multiclusterengine
 // linked to a service in the provided namespace
 func ValidatingWebhook(namespace string) *admissionregistration.ValidatingWebhookConfiguration {
@@ -159,41 +162,15 @@ func (r *MultiClusterEngine) ValidateCreate() (admission.Warnings, error) {
 	ctx := context.Background()
 	backplaneconfiglog.Info("validate create", "name", r.Name)
 
-	if (r.Spec.AvailabilityConfig != HABasic) && (r.Spec.AvailabilityConfig != HAHigh) && (r.Spec.AvailabilityConfig != "") {
-		return nil, ErrInvalidAvailability
-	}
-
-	// Validate components
-	if r.Spec.Overrides != nil {
-		for _, c := range r.Spec.Overrides.Components {
-			if !validComponent(c) {
-				return nil, fmt.Errorf("%w: %s is not a known component", ErrInvalidComponent, c.Name)
-			}
-		}
+	if err := r.ValidateSpec(); err != nil {
+		return nil, err
 	}
 
 	mceList := &MultiClusterEngineList{}
 	if err := Client.List(ctx, mceList); err != nil {
 		return nil, fmt.Errorf("unable to list BackplaneConfigs: %s", err)
 	}
-
-	targetNS := r.Spec.TargetNamespace
-	if targetNS == "" {
-		targetNS = DefaultTargetNamespace
-	}
-
-	for _, mce := range mceList.Items {
-		mce := mce
-		if mce.Spec.TargetNamespace == targetNS || (targetNS == DefaultTargetNamespace && mce.Spec.TargetNamespace == "") {
-			return nil, fmt.Errorf("%w: MultiClusterEngine with targetNamespace already exists: '%s'",
-				ErrInvalidNamespace, mce.Name)
-		}
-		if !IsInHostedMode(r) && !IsInHostedMode(&mce) {
-			return nil, fmt.Errorf("%w: MultiClusterEngine in Standalone mode already exists: `%s`. "+
-				"Only one resource may exist in Standalone mode.", ErrInvalidDeployMode, mce.Name)
-		}
-	}
-	return nil, nil
+	return nil, r.ValidateWithExisting(mceList.Items)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -202,64 +179,18 @@ func (r *MultiClusterEngine) ValidateUpdate(old runtime.Object) (admission.Warni
 
 	oldMCE := old.(*MultiClusterEngine)
 	backplaneconfiglog.Info(oldMCE.Spec.TargetNamespace)
-	if (r.Spec.TargetNamespace != oldMCE.Spec.TargetNamespace) && (oldMCE.Spec.TargetNamespace != "") {
-		return nil, fmt.Errorf("%w: changes cannot be made to target namespace", ErrInvalidNamespace)
-	}
-	if IsInHostedMode(r) != IsInHostedMode(oldMCE) {
-		return nil, fmt.Errorf("%w: changes cannot be made to DeploymentMode", ErrInvalidDeployMode)
-	}
-
-	oldNS, newNS := "", ""
-	if oldMCE.Spec.Overrides != nil {
-		oldNS = oldMCE.Spec.Overrides.InfrastructureCustomNamespace
-	}
-	if r.Spec.Overrides != nil {
-		newNS = r.Spec.Overrides.InfrastructureCustomNamespace
-	}
-	if oldNS != newNS {
-		return nil, fmt.Errorf("%w: changes cannot be made to InfrastructureCustomNamespace", ErrInvalidInfraNS)
-	}
-
-	if (r.Spec.AvailabilityConfig != HABasic) && (r.Spec.AvailabilityConfig != HAHigh) && (r.Spec.AvailabilityConfig != "") {
-		return nil, ErrInvalidAvailability
-	}
-
-	// Validate components
-	if r.Spec.Overrides != nil {
-		for _, c := range r.Spec.Overrides.Components {
-			if !validComponent(c) {
-				return nil, fmt.Errorf("%w: %s is not a known component", ErrInvalidComponent, c.Name)
-			}
-		}
+	if err := r.ValidateSpecUpdate(oldMCE); err != nil {
+		return nil, err
 	}
 
 	// Block disable if relevant resources present
-	if r.ComponentPresent(Discovery) && !r.Enabled(Discovery) {
-		cfg, err := config.GetConfig()
-		if err != nil {
-			return nil, err
-		}
-
-		c, err := discovery.NewDiscoveryClientForConfig(cfg)
+	for _, resource := range r.disabledComponentBlockers() {
+		items, err := listBlockingResource(context.TODO(), resource)
 		if err != nil {
 			return nil, err
 		}
-
-		gvk := schema.GroupVersionKind{
-			Group:   "discovery.open-cluster-management.io",
-			Version: "v1",
-			Kind:    "DiscoveryConfigList",
-		}
-		list := &unstructured.UnstructuredList{}
-		list.SetGroupVersionKind(gvk)
-		err = discovery.ServerSupportsVersion(c, gvk.GroupVersion())
-		if err == nil {
-			if err := Client.List(context.TODO(), list); err != nil {
-				return nil, fmt.Errorf("unable to list %s: %s", "DiscoveryConfig", err)
-			}
-			if len(list.Items) != 0 {
-				return nil, fmt.Errorf("existing %s resources must first be deleted", "DiscoveryConfig")
-			}
+		if len(items) != 0 {
+			return nil, fmt.Errorf("existing %s resources must first be deleted", resource.Name)
 		}
 	}
 
@@ -272,27 +203,12 @@ func (r *MultiClusterEngine) ValidateDelete() (admission.Warnings, error) {
 	backplaneconfiglog.Info("validate delete", "name", r.Name)
 	ctx := context.Background()
 
-	cfg, err := config.GetConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	c, err := discovery.NewDiscoveryClientForConfig(cfg)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, resource := range blockDeletionResources {
-		list := &unstructured.UnstructuredList{}
-		list.SetGroupVersionKind(resource.GVK)
-		err := discovery.ServerSupportsVersion(c, list.GroupVersionKind().GroupVersion())
+		items, err := listBlockingResource(ctx, resource)
 		if err != nil {
-			continue
-		}
-		if err := Client.List(ctx, list); err != nil {
-			return nil, fmt.Errorf("unable to list %s: %s", resource.Name, err)
+			return nil, err
 		}
-		for _, item := range list.Items {
+		for _, item := range items {
 			if !contains(resource.Exceptions, item.GetName()) {
 				return nil, fmt.Errorf("cannot delete %s resource. Existing %s resources must first be deleted",
 					r.Name, resource.Name)
@@ -302,6 +218,20 @@ func (r *MultiClusterEngine) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// listBlockingResource lists the instances of the resource on the cluster. No instances are returned if
+// the cluster does not serve the resource.
+func listBlockingResource(ctx context.Context, resource blockingResource) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(resource.GVK)
+	if err := Client.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list %s: %s", resource.Name, err)
+	}
+	return list.Items, nil
+}
+
 func contains(s []string, v string) bool {
 	for _, vs := range s {
 		if vs == v {
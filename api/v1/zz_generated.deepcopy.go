@@ -23,6 +23,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -43,6 +44,22 @@ func (in *ComponentCondition) DeepCopy() *ComponentCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDeadline) DeepCopyInto(out *ComponentDeadline) {
+	*out = *in
+	out.Deadline = in.Deadline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDeadline.
+func (in *ComponentDeadline) DeepCopy() *ComponentDeadline {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDeadline)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentConfig) DeepCopyInto(out *ComponentConfig) {
 	*out = *in
@@ -58,6 +75,41 @@ func (in *ComponentConfig) DeepCopy() *ComponentConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.ComponentUnavailableFor != nil {
+		in, out := &in.ComponentUnavailableFor, &out.ComponentUnavailableFor
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReconcileErrorsThreshold != nil {
+		in, out := &in.ReconcileErrorsThreshold, &out.ReconcileErrorsThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PhaseErrorFor != nil {
+		in, out := &in.PhaseErrorFor, &out.PhaseErrorFor
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UpgradeStuckFor != nil {
+		in, out := &in.UpgradeStuckFor, &out.UpgradeStuckFor
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiClusterEngine) DeepCopyInto(out *MultiClusterEngine) {
 	*out = *in
@@ -144,6 +196,11 @@ func (in *MultiClusterEngineSpec) DeepCopyInto(out *MultiClusterEngineSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Overrides != nil {
 		in, out := &in.Overrides, &out.Overrides
 		*out = new(Overrides)
@@ -156,6 +213,21 @@ func (in *MultiClusterEngineSpec) DeepCopyInto(out *MultiClusterEngineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UninstallPolicy != nil {
+		in, out := &in.UninstallPolicy, &out.UninstallPolicy
+		*out = new(UninstallPolicy)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessDeadlines != nil {
+		in, out := &in.ReadinessDeadlines, &out.ReadinessDeadlines
+		*out = new(ReadinessDeadlines)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterEngineSpec.
@@ -216,3 +288,43 @@ func (in *Overrides) DeepCopy() *Overrides {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessDeadlines) DeepCopyInto(out *ReadinessDeadlines) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentDeadline, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessDeadlines.
+func (in *ReadinessDeadlines) DeepCopy() *ReadinessDeadlines {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessDeadlines)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UninstallPolicy) DeepCopyInto(out *UninstallPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UninstallPolicy.
+func (in *UninstallPolicy) DeepCopy() *UninstallPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UninstallPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
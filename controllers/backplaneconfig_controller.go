@@ -29,6 +29,8 @@ import (
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/pkg/foundation"
 	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/metrics"
+	"github.com/stolostron/backplane-operator/pkg/platform"
 	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/utils"
@@ -36,6 +38,7 @@ import (
 	clustermanager "open-cluster-management.io/api/operator/v1"
 
 	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 	hiveconfig "github.com/openshift/hive/apis/hive/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -47,10 +50,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -58,7 +63,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	semver "github.com/Masterminds/semver"
 	pkgerrors "github.com/pkg/errors"
 	monitorv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 )
@@ -70,6 +74,29 @@ type MultiClusterEngineReconciler struct {
 	Images          map[string]string
 	StatusManager   *status.StatusTracker
 	UpgradeableCond utils.Condition
+	// Platform describes the cluster the operator runs on. OpenShift is assumed when unset.
+	Platform platform.Platform
+	// Recorder records events on the MultiClusterEngine. No events are recorded when unset.
+	Recorder record.EventRecorder
+	// CRDs are the CRDs applied by the CRD controller, whose health is reported. It is not reported when unset.
+	CRDs []*unstructured.Unstructured
+
+	// events deduplicates the events recorded for transitions across reconciles
+	events *transitionRecorder
+
+	// configRefs are the configmaps and secrets each MultiClusterEngine reads, used to filter their watches
+	configRefs *configRefs
+
+	// summaries caches the image keys and namespaces of the components rendered for each MultiClusterEngine
+	summaries *componentSummaries
+
+	// configHash is the hash of the config consumed by pods in the current reconcile
+	configHash string
+	// pullSecretNamespaces are the namespaces image pull secrets are copied to, set from the spec in
+	// the current reconcile
+	pullSecretNamespaces map[string]bool
+	// pullSecretsSynced are the namespaces image pull secrets were synced to in the current reconcile
+	pullSecretsSynced map[string]bool
 }
 
 const (
@@ -84,7 +111,7 @@ const (
 //+kubebuilder:rbac:groups=multicluster.openshift.io,resources=multiclusterengines/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=multicluster.openshift.io,resources=multiclusterengines/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apiextensions.k8s.io;rbac.authorization.k8s.io;"";apps,resources=deployments;serviceaccounts;customresourcedefinitions;clusterrolebindings;clusterroles,verbs=get;create;update;list
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;update;list;watch;delete;patch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;create;update;list;watch;delete;patch
 //+kubebuilder:rbac:groups="discovery.open-cluster-management.io",resources=discoveryconfigs,verbs=get
 //+kubebuilder:rbac:groups="discovery.open-cluster-management.io",resources=discoveryconfigs,verbs=list
 //+kubebuilder:rbac:groups="discovery.open-cluster-management.io",resources=discoveryconfigs;discoveredclusters,verbs=create;get;list;watch;update;delete;deletecollection;patch;approve;escalate;bind
@@ -152,10 +179,18 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 	} else if err != nil && apierrors.IsNotFound(err) {
 		// BackplaneConfig deleted or not found
 		// Return and don't requeue
+		metrics.Delete(req.Name)
+		r.events.forget(req.Name)
+		r.configRefs.forget(req.Name)
+		r.summaries.forget(req.Name)
 		return ctrl.Result{}, nil
 	}
+	r.configRefs.set(backplaneConfig)
 
 	// reset status manager
+	r.configHash = ""
+	r.pullSecretNamespaces = map[string]bool{}
+	r.pullSecretsSynced = map[string]bool{}
 	r.StatusManager.Reset("")
 	for _, c := range backplaneConfig.Status.Conditions {
 		r.StatusManager.AddCondition(c)
@@ -176,7 +211,10 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	defer func() {
 		log.Info("Updating status")
+		previous := backplaneConfig.Status
 		backplaneConfig.Status = r.StatusManager.ReportStatus(*backplaneConfig)
+		metrics.ReportStatus(backplaneConfig)
+		r.recordTransitions(backplaneConfig, previous)
 		err := r.Client.Status().Update(ctx, backplaneConfig)
 		if backplaneConfig.Status.Phase != backplanev1.MultiClusterEnginePhaseAvailable && !utils.IsPaused(backplaneConfig) {
 			retRes = ctrl.Result{RequeueAfter: requeuePeriod}
@@ -206,6 +244,7 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 			if err := r.Client.Update(ctx, backplaneConfig); err != nil {
 				return ctrl.Result{}, err
 			}
+			r.events.once(backplaneConfig, "finalized", corev1.EventTypeNormal, finalizedEvent, "All components were removed")
 		}
 
 		return ctrl.Result{}, nil // Object finalized successfully
@@ -229,7 +268,12 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	if !utils.ShouldIgnoreOCPVersion(backplaneConfig) {
+	err = r.setClusterProxy(ctx)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if r.platform().Name() == platform.OpenShift && !utils.ShouldIgnoreOCPVersion(backplaneConfig) {
 		currentOCPVersion, err := r.getClusterVersion(ctx)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to detect clusterversion: %w", err)
@@ -256,8 +300,13 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{Requeue: true}, err
 	}
 
+	r.configHash, err = r.computeConfigHash(ctx, backplaneConfig)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
 	// Read images from environmental variables
-	imgs, err := images.GetImagesWithOverrides(r.Client, backplaneConfig)
+	imgs, overrideIssues, err := images.GetImagesWithOverrides(r.Client, backplaneConfig)
 	if err != nil {
 		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionFalse, status.RequirementsNotMetReason, fmt.Sprintf("Issue building image references: %s", err.Error())))
 		return ctrl.Result{}, err
@@ -267,6 +316,11 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionFalse, status.RequirementsNotMetReason, "No image references defined in deployment"))
 		return ctrl.Result{RequeueAfter: requeuePeriod}, errors.New("no image references exist. images must be defined as environment variables")
 	}
+	r.setImageOverridesCondition(backplaneConfig, overrideIssues)
+	imgs, err = r.resolveImageMirrors(ctx, backplaneConfig, imgs)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
 	r.Images = imgs
 
 	// Do not reconcile objects if this instance of mce is labeled "paused"
@@ -282,6 +336,10 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.setPullSecretNamespaces(ctx, backplaneConfig); err != nil {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, err
+	}
+
 	result, err = r.DeployAlwaysSubcomponents(ctx, backplaneConfig)
 	if err != nil {
 		cond := status.NewCondition(
@@ -294,6 +352,15 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return result, err
 	}
 
+	if err := r.ensureImageList(ctx, backplaneConfig); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err = r.reconcileComponent(ctx, monitoringComponent, backplaneConfig, r.ensureMonitoring)
+	if err != nil {
+		return result, err
+	}
+
 	result, err = r.ensureToggleableComponents(ctx, backplaneConfig)
 	if err != nil {
 		return result, err
@@ -309,6 +376,10 @@ func (r *MultiClusterEngineReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return result, err
 	}
 
+	if err := r.prunePullSecrets(ctx, backplaneConfig); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if upgrade {
 		return ctrl.Result{Requeue: true}, nil
 	}
@@ -365,11 +436,19 @@ func (r *MultiClusterEngineReconciler) setOperatorUpgradeableStatus(ctx context.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MultiClusterEngineReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&backplanev1.MultiClusterEngine{}).
-		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})).
+	// Spec, label and annotation changes. Watches of config content, such as the trust bundle, need
+	// every update, so this is not applied as a controller-wide event filter.
+	resourceChanged := builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))
+
+	r.events = newTransitionRecorder(r.Recorder)
+	r.configRefs = newConfigRefs()
+	r.summaries = newComponentSummaries()
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&backplanev1.MultiClusterEngine{}, resourceChanged).
 		Watches(&appsv1.Deployment{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &backplanev1.MultiClusterEngine{}),
+			resourceChanged,
 		).
 		Watches(&hiveconfig.HiveConfig{}, &handler.Funcs{
 			DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
@@ -393,7 +472,24 @@ func (r *MultiClusterEngineReconciler) SetupWithManager(mgr ctrl.Manager) error
 				}})
 			},
 		}, builder.WithPredicates(predicate.LabelChangedPredicate{})).
-		Watches(&monitorv1.ServiceMonitor{}, &handler.Funcs{
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.configMapToMultiClusterEngines),
+			builder.OnlyMetadata,
+			builder.WithPredicates(r.configRefs.readBy("ConfigMap"), predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.pullSecretToMultiClusterEngines),
+			builder.OnlyMetadata,
+			builder.WithPredicates(r.configRefs.readBy("Secret"), predicate.ResourceVersionChangedPredicate{}),
+		)
+
+	// ServiceMonitors only exist where the prometheus-operator CRDs are installed
+	served, err := kindServed(mgr, &monitorv1.ServiceMonitor{})
+	if err != nil {
+		return err
+	}
+	if served {
+		b = b.Watches(&monitorv1.ServiceMonitor{}, &handler.Funcs{
 			DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
 				labels := e.Object.GetLabels()
 				if label, ok := labels["backplaneconfig.name"]; ok {
@@ -402,24 +498,60 @@ func (r *MultiClusterEngineReconciler) SetupWithManager(mgr ctrl.Manager) error
 					}})
 				}
 			},
-		}, builder.WithPredicates(predicate.LabelChangedPredicate{})).
-		Watches(&configv1.ClusterVersion{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
-				req := []reconcile.Request{}
-				multiclusterengineList := &backplanev1.MultiClusterEngineList{}
-				if err := r.Client.List(ctx, multiclusterengineList); err == nil && len(multiclusterengineList.Items) > 0 {
-					for _, mce := range multiclusterengineList.Items {
-						tmpreq := reconcile.Request{
-							NamespacedName: types.NamespacedName{
-								Name: mce.GetName(),
-							},
-						}
-						req = append(req, tmpreq)
-					}
-				}
-				return req
-			})).
-		Complete(r)
+		}, builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+
+	// Mirror configuration changes the images resolved from it. Only watch the kinds the cluster serves.
+	for _, obj := range []client.Object{&configv1.ImageDigestMirrorSet{}, &operatorv1alpha1.ImageContentSourcePolicy{}} {
+		served, err := kindServed(mgr, obj)
+		if err != nil {
+			return err
+		}
+		if served {
+			b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(r.allMultiClusterEngines), resourceChanged)
+		}
+	}
+
+	// ClusterVersion and Proxy only exist on OpenShift
+	if r.platform().Name() == platform.OpenShift {
+		b = b.Watches(&configv1.ClusterVersion{},
+			handler.EnqueueRequestsFromMapFunc(r.allMultiClusterEngines), resourceChanged).
+			Watches(&configv1.Proxy{},
+				handler.EnqueueRequestsFromMapFunc(r.allMultiClusterEngines),
+				builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+					return o.GetName() == "cluster"
+				})),
+			)
+	}
+	return b.Complete(r)
+}
+
+// kindServed returns true if the cluster serves the kind of obj. A watch on a kind that isn't served
+// never syncs, which stops the manager from starting.
+func kindServed(mgr ctrl.Manager, obj client.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return false, err
+	}
+	_, err = mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	return err == nil, nil
+}
+
+// allMultiClusterEngines maps an event to a request for every MultiClusterEngine
+func (r *MultiClusterEngineReconciler) allMultiClusterEngines(ctx context.Context, a client.Object) []reconcile.Request {
+	req := []reconcile.Request{}
+	multiclusterengineList := &backplanev1.MultiClusterEngineList{}
+	if err := r.Client.List(ctx, multiclusterengineList); err == nil && len(multiclusterengineList.Items) > 0 {
+		for _, mce := range multiclusterengineList.Items {
+			tmpreq := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: mce.GetName(),
+				},
+			}
+			req = append(req, tmpreq)
+		}
+	}
+	return req
 }
 
 // createTrustBundleConfigmap creates a configmap that will be injected with the
@@ -428,11 +560,8 @@ func (r *MultiClusterEngineReconciler) createTrustBundleConfigmap(ctx context.Co
 	log := log.FromContext(ctx)
 
 	// Get Trusted Bundle configmap name
-	trustBundleName := defaultTrustBundleName
+	trustBundleName := trustBundleName()
 	trustBundleNamespace := mce.Spec.TargetNamespace
-	if name, ok := os.LookupEnv(trustBundleNameEnvVar); ok && name != "" {
-		trustBundleName = name
-	}
 	namespacedName := types.NamespacedName{
 		Name:      trustBundleName,
 		Namespace: trustBundleNamespace,
@@ -492,6 +621,11 @@ func (r *MultiClusterEngineReconciler) DeployAlwaysSubcomponents(ctx context.Con
 		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
 	}
 
+	// Report health of the CRDs applied by the CRD controller
+	if len(r.CRDs) > 0 {
+		r.StatusManager.AddComponent(status.NewCRDStatus(r.CRDs))
+	}
+
 	// Applies all templates
 	for _, template := range templates {
 		if template.GetKind() == "Deployment" {
@@ -518,7 +652,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	requeue := false
 
 	if backplaneConfig.Enabled(backplanev1.ManagedServiceAccount) {
-		result, err := r.ensureManagedServiceAccount(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ManagedServiceAccount, backplaneConfig, r.ensureManagedServiceAccount)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -526,7 +660,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.ManagedServiceAccount] = err
 		}
 	} else {
-		result, err := r.ensureNoManagedServiceAccount(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ManagedServiceAccount, backplaneConfig, r.ensureNoManagedServiceAccount)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -536,7 +670,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.HyperShift) {
-		result, err := r.ensureHyperShift(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.HyperShift, backplaneConfig, r.ensureHyperShift)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -544,7 +678,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.HyperShift] = err
 		}
 	} else {
-		result, err := r.ensureNoHyperShift(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.HyperShift, backplaneConfig, r.ensureNoHyperShift)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -552,7 +686,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.HyperShift] = err
 		}
 	}
-	result, err := r.reconcileHypershiftLocalHosting(ctx, backplaneConfig)
+	result, err := r.reconcileComponent(ctx, backplanev1.HypershiftLocalHosting, backplaneConfig, r.reconcileHypershiftLocalHosting)
 	if result != (ctrl.Result{}) {
 		requeue = true
 	}
@@ -566,7 +700,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.ConsoleMCE) && ocpConsole {
-		result, err := r.ensureConsoleMCE(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ConsoleMCE, backplaneConfig, r.ensureConsoleMCE)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -574,7 +708,9 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.ConsoleMCE] = err
 		}
 	} else {
-		result, err := r.ensureNoConsoleMCE(ctx, backplaneConfig, ocpConsole)
+		result, err := r.reconcileComponent(ctx, backplanev1.ConsoleMCE, backplaneConfig, func(ctx context.Context, mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+			return r.ensureNoConsoleMCE(ctx, mce, ocpConsole)
+		})
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -584,7 +720,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.Discovery) {
-		result, err := r.ensureDiscovery(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.Discovery, backplaneConfig, r.ensureDiscovery)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -592,7 +728,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.Discovery] = err
 		}
 	} else {
-		result, err := r.ensureNoDiscovery(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.Discovery, backplaneConfig, r.ensureNoDiscovery)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -602,7 +738,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.Hive) {
-		result, err := r.ensureHive(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.Hive, backplaneConfig, r.ensureHive)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -610,7 +746,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.Hive] = err
 		}
 	} else {
-		result, err := r.ensureNoHive(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.Hive, backplaneConfig, r.ensureNoHive)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -620,7 +756,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.AssistedService) {
-		result, err := r.ensureAssistedService(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.AssistedService, backplaneConfig, r.ensureAssistedService)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -628,7 +764,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.AssistedService] = err
 		}
 	} else {
-		result, err := r.ensureNoAssistedService(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.AssistedService, backplaneConfig, r.ensureNoAssistedService)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -638,7 +774,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.ClusterLifecycle) {
-		result, err := r.ensureClusterLifecycle(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ClusterLifecycle, backplaneConfig, r.ensureClusterLifecycle)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -646,7 +782,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.ClusterLifecycle] = err
 		}
 	} else {
-		result, err := r.ensureNoClusterLifecycle(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ClusterLifecycle, backplaneConfig, r.ensureNoClusterLifecycle)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -656,7 +792,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.ClusterManager) {
-		result, err := r.ensureClusterManager(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ClusterManager, backplaneConfig, r.ensureClusterManager)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -664,7 +800,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.ClusterManager] = err
 		}
 	} else {
-		result, err := r.ensureNoClusterManager(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ClusterManager, backplaneConfig, r.ensureNoClusterManager)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -674,7 +810,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.ServerFoundation) {
-		result, err := r.ensureServerFoundation(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ServerFoundation, backplaneConfig, r.ensureServerFoundation)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -682,7 +818,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.ServerFoundation] = err
 		}
 	} else {
-		result, err := r.ensureNoServerFoundation(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ServerFoundation, backplaneConfig, r.ensureNoServerFoundation)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -692,7 +828,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.ClusterProxyAddon) {
-		result, err := r.ensureClusterProxyAddon(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ClusterProxyAddon, backplaneConfig, r.ensureClusterProxyAddon)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -700,7 +836,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.ClusterProxyAddon] = err
 		}
 	} else {
-		result, err := r.ensureNoClusterProxyAddon(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.ClusterProxyAddon, backplaneConfig, r.ensureNoClusterProxyAddon)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -710,7 +846,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 	}
 
 	if backplaneConfig.Enabled(backplanev1.LocalCluster) {
-		result, err := r.ensureLocalCluster(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.LocalCluster, backplaneConfig, r.ensureLocalCluster)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -718,7 +854,7 @@ func (r *MultiClusterEngineReconciler) ensureToggleableComponents(ctx context.Co
 			errs[backplanev1.LocalCluster] = err
 		}
 	} else {
-		result, err := r.ensureNoLocalCluster(ctx, backplaneConfig)
+		result, err := r.reconcileComponent(ctx, backplanev1.LocalCluster, backplaneConfig, r.ensureNoLocalCluster)
 		if result != (ctrl.Result{}) {
 			requeue = true
 		}
@@ -752,6 +888,16 @@ func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context, backpl
 		}
 	}
 
+	// Track the health of the kinds that report it
+	if sr := status.NewResourceStatus(template); sr != nil {
+		r.StatusManager.AddComponent(sr)
+	}
+
+	// Roll out pods when the config they consume changes
+	if err := setConfigHash(template, r.configHash); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error setting config hash on resource Name: %s Kind: %s Error: %w", template.GetName(), template.GetKind(), err)
+	}
+
 	if template.GetKind() == "APIService" {
 		result, err := r.ensureUnstructuredResource(ctx, backplaneConfig, template)
 		if err != nil {
@@ -762,9 +908,14 @@ func (r *MultiClusterEngineReconciler) applyTemplate(ctx context.Context, backpl
 		force := true
 		err := r.Client.Patch(ctx, template, client.Apply, &client.PatchOptions{Force: &force, FieldManager: "backplane-operator"})
 		if err != nil {
+			metrics.ApplyErrors.WithLabelValues(componentFromContext(ctx), template.GetKind()).Inc()
 			return ctrl.Result{}, fmt.Errorf("error applying object Name: %s Kind: %s Error: %w", template.GetName(), template.GetKind(), err)
 		}
 	}
+
+	if err := r.ensurePullSecrets(ctx, backplaneConfig, template.GetNamespace()); err != nil {
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -781,6 +932,7 @@ func (r *MultiClusterEngineReconciler) deleteTemplate(ctx context.Context, backp
 	// set status progressing condition
 	if err != nil {
 		log.Error(err, "Odd error delete template")
+		metrics.DeleteErrors.WithLabelValues(componentFromContext(ctx), template.GetKind()).Inc()
 		return ctrl.Result{}, err
 	}
 
@@ -788,6 +940,7 @@ func (r *MultiClusterEngineReconciler) deleteTemplate(ctx context.Context, backp
 	err = r.Client.Delete(ctx, template)
 	if err != nil {
 		log.Error(err, "Failed to delete template")
+		metrics.DeleteErrors.WithLabelValues(componentFromContext(ctx), template.GetKind()).Inc()
 		return ctrl.Result{}, err
 	}
 
@@ -866,6 +1019,7 @@ func (r *MultiClusterEngineReconciler) finalizeBackplaneConfig(ctx context.Conte
 
 		return fmt.Errorf("waiting for 'hypershift-addon' ManagedClusterAddOn to be terminated before proceeding with uninstallation")
 	}
+	r.finalizeStepCompleted(backplaneConfig, "ManagedClusterAddOn hypershift-addon")
 
 	localCluster := &unstructured.Unstructured{}
 	localCluster.SetGroupVersionKind(
@@ -898,6 +1052,7 @@ func (r *MultiClusterEngineReconciler) finalizeBackplaneConfig(ctx context.Conte
 		log.Error(err, "error while looking for local-cluster ManagedCluster CR")
 		return err
 	}
+	r.finalizeStepCompleted(backplaneConfig, "ManagedCluster local-cluster")
 
 	clusterManager := &unstructured.Unstructured{}
 	clusterManager.SetGroupVersionKind(
@@ -928,6 +1083,7 @@ func (r *MultiClusterEngineReconciler) finalizeBackplaneConfig(ctx context.Conte
 	} else if err != nil && !apierrors.IsNotFound(err) { // Return error, if error is not not found error
 		return err
 	}
+	r.finalizeStepCompleted(backplaneConfig, "ClusterManager cluster-manager")
 
 	ocmHubNamespace := &corev1.Namespace{}
 	err = r.Client.Get(ctx, types.NamespacedName{Name: "open-cluster-management-hub"}, ocmHubNamespace)
@@ -945,6 +1101,14 @@ func (r *MultiClusterEngineReconciler) finalizeBackplaneConfig(ctx context.Conte
 	} else if err != nil && !apierrors.IsNotFound(err) { // Return error, if error is not not found error
 		return err
 	}
+	r.finalizeStepCompleted(backplaneConfig, "Namespace open-cluster-management-hub")
+
+	if backplaneConfig.ShouldDeleteCRDs() {
+		if err := r.removeCRDs(ctx, backplaneConfig); err != nil {
+			return err
+		}
+		r.finalizeStepCompleted(backplaneConfig, "CustomResourceDefinitions")
+	}
 
 	globalSetNamespace := &corev1.Namespace{}
 	err = r.Client.Get(ctx, types.NamespacedName{Name: "open-cluster-management-global-set"}, globalSetNamespace)
@@ -960,6 +1124,11 @@ func (r *MultiClusterEngineReconciler) finalizeBackplaneConfig(ctx context.Conte
 	return nil
 }
 
+// finalizeStepCompleted records an event the first time a resource removed by the finalizer is found gone
+func (r *MultiClusterEngineReconciler) finalizeStepCompleted(mce *backplanev1.MultiClusterEngine, resource string) {
+	r.events.once(mce, "finalize/"+resource, corev1.EventTypeNormal, finalizeStepCompletedEvent, fmt.Sprintf("%s was removed", resource))
+}
+
 func (r *MultiClusterEngineReconciler) getBackplaneConfig(ctx context.Context, req ctrl.Request) (*backplanev1.MultiClusterEngine, error) {
 	log := log.FromContext(ctx)
 	backplaneConfig := &backplanev1.MultiClusterEngine{}
@@ -998,6 +1167,7 @@ func (r *MultiClusterEngineReconciler) ensureUnstructuredResource(ctx context.Co
 		if err != nil {
 			// Creation failed
 			log.Error(err, "Failed to create new instance")
+			metrics.ApplyErrors.WithLabelValues(componentFromContext(ctx), u.GetKind()).Inc()
 			return ctrl.Result{}, err
 		}
 		// Creation was successful
@@ -1009,6 +1179,7 @@ func (r *MultiClusterEngineReconciler) ensureUnstructuredResource(ctx context.Co
 	} else if err != nil {
 		// Error that isn't due to the resource not existing
 		log.Error(err, "Failed to get resource")
+		metrics.ApplyErrors.WithLabelValues(componentFromContext(ctx), u.GetKind()).Inc()
 		return ctrl.Result{}, err
 	}
 
@@ -1018,30 +1189,6 @@ func (r *MultiClusterEngineReconciler) ensureUnstructuredResource(ctx context.Co
 func (r *MultiClusterEngineReconciler) setDefaults(ctx context.Context, m *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
-	updateNecessary := false
-	if !utils.AvailabilityConfigIsValid(m.Spec.AvailabilityConfig) {
-		m.Spec.AvailabilityConfig = backplanev1.HAHigh
-		updateNecessary = true
-	}
-
-	if len(m.Spec.TargetNamespace) == 0 {
-		m.Spec.TargetNamespace = backplanev1.DefaultTargetNamespace
-		updateNecessary = true
-	}
-
-	if utils.SetDefaultComponents(m) {
-		updateNecessary = true
-	}
-
-	// hyper-shift preview component upgraded in 2.8.0
-	if m.Prune(backplanev1.HyperShiftPreview) {
-		updateNecessary = true
-	}
-
-	if utils.DeduplicateComponents(m) {
-		updateNecessary = true
-	}
-
 	// Set and store cluster Ingress domain for use later
 	clusterIngressDomain, err := r.getClusterIngressDomain(ctx, m)
 	if err != nil {
@@ -1057,38 +1204,16 @@ func (r *MultiClusterEngineReconciler) setDefaults(ctx context.Context, m *backp
 		return ctrl.Result{}, pkgerrors.Wrapf(err, "failed to detect clusterversion")
 	}
 
-	// Set OCP version as env var, so that charts can render this value
+	// Set cluster version and platform as env vars, so that charts can render these values
 	os.Setenv("ACM_HUB_OCP_VERSION", currentClusterVersion)
+	os.Setenv("ACM_HUB_PLATFORM", r.platform().Name())
 
-	currentVersion, err := semver.NewVersion(currentClusterVersion)
+	updateNecessary, err := utils.SetDefaults(m, r.platform().Name(), currentClusterVersion)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Failed to convert currentClusterVersion %s to semver compatible value for comparison", currentClusterVersion))
+		log.Error(err, "Failed to set defaults")
 		return ctrl.Result{}, err
 	}
 
-	// -0 allows for prerelease builds to pass the validation.
-	// If -0 is removed, developer/rc builds will not pass this check
-	constraint, err := semver.NewConstraint(">= 4.10.0-0")
-	if err != nil {
-		log.Error(err, "Failed to set constraint of minimum supported version for plugins")
-		return ctrl.Result{}, err
-	}
-
-	if constraint.Check(currentVersion) {
-		// If ConsoleMCE config already exists, then don't overwrite it
-		if !m.ComponentPresent(backplanev1.ConsoleMCE) {
-			log.Info("Dynamic plugins are supported. ConsoleMCE Config is not detected. Enabling ConsoleMCE")
-			m.Enable(backplanev1.ConsoleMCE)
-			updateNecessary = true
-		}
-	} else {
-		if m.Enabled(backplanev1.ConsoleMCE) {
-			log.Info("Dynamic plugins are not supported. Disabling MCE console")
-			m.Disable(backplanev1.ConsoleMCE)
-			updateNecessary = true
-		}
-	}
-
 	// Apply defaults to server
 	if updateNecessary {
 		log.Info("Setting defaults")
@@ -1132,27 +1257,58 @@ func (r *MultiClusterEngineReconciler) validateNamespace(ctx context.Context, m
 }
 
 // validateImagePullSecret returns an error if the namespace in spec.targetNamespace does not have a secret
-// with the name in spec.imagePullSecret.
+// with the name in spec.imagePullSecret or spec.imagePullSecrets.
 func (r *MultiClusterEngineReconciler) validateImagePullSecret(ctx context.Context, m *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
-	if m.Spec.ImagePullSecret == "" {
-		return ctrl.Result{}, nil
+	for _, name := range m.PullSecrets() {
+		pullSecret := &corev1.Secret{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      name,
+			Namespace: m.Spec.TargetNamespace,
+		}, pullSecret)
+		if apierrors.IsNotFound(err) {
+			missingPullSecret := status.NewCondition(backplanev1.MultiClusterEngineConditionType(backplanev1.MultiClusterEngineProgressing), metav1.ConditionFalse, status.RequirementsNotMetReason, fmt.Sprintf("Could not find imagePullSecret %s in namespace %s", name, m.Spec.TargetNamespace))
+			r.StatusManager.AddCondition(missingPullSecret)
+			return ctrl.Result{RequeueAfter: requeuePeriod}, err
+		}
+		if err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
 	}
 
-	pullSecret := &corev1.Secret{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{
-		Name:      m.Spec.ImagePullSecret,
-		Namespace: m.Spec.TargetNamespace,
-	}, pullSecret)
-	if apierrors.IsNotFound(err) {
-		missingPullSecret := status.NewCondition(backplanev1.MultiClusterEngineConditionType(backplanev1.MultiClusterEngineProgressing), metav1.ConditionFalse, status.RequirementsNotMetReason, fmt.Sprintf("Could not find imagePullSecret %s in namespace %s", m.Spec.ImagePullSecret, m.Spec.TargetNamespace))
-		r.StatusManager.AddCondition(missingPullSecret)
-		return ctrl.Result{RequeueAfter: requeuePeriod}, err
+	return ctrl.Result{}, nil
+}
+
+//+kubebuilder:rbac:groups=config.openshift.io,resources=imagedigestmirrorsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=operator.openshift.io,resources=imagecontentsourcepolicies,verbs=get;list;watch
+
+// resolveImageMirrors replaces images with their cluster mirrors when the MultiClusterEngine is
+// annotated to resolve mirrors, and reports any image without a mirror in the ImagesMirrored condition
+func (r *MultiClusterEngineReconciler) resolveImageMirrors(ctx context.Context, mce *backplanev1.MultiClusterEngine, imgs map[string]string) (map[string]string, error) {
+	if !utils.ShouldResolveImageMirrors(mce) {
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineImagesMirrored)
+		return imgs, nil
 	}
+
+	mirrors, err := images.GetMirrors(ctx, r.Client)
 	if err != nil {
-		return ctrl.Result{Requeue: true}, err
+		return nil, fmt.Errorf("failed to read image mirrors: %w", err)
+	}
+	resolved, unmirrored := images.ResolveMirrors(imgs, mirrors)
+	if len(unmirrored) > 0 {
+		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineImagesMirrored, metav1.ConditionFalse, status.ImagesNotMirroredReason,
+			fmt.Sprintf("No mirror found for images: %s", strings.Join(unmirrored, ", "))))
+	} else {
+		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineImagesMirrored, metav1.ConditionTrue, status.AllImagesMirroredReason, ""))
 	}
+	return resolved, nil
+}
 
-	return ctrl.Result{}, nil
+// platform returns the platform the operator runs on, defaulting to OpenShift
+func (r *MultiClusterEngineReconciler) platform() platform.Platform {
+	if r.Platform == nil {
+		r.Platform = platform.NewOpenShiftPlatform(true)
+	}
+	return r.Platform
 }
 
 func (r *MultiClusterEngineReconciler) getClusterVersion(ctx context.Context) (string, error) {
@@ -1165,18 +1321,24 @@ func (r *MultiClusterEngineReconciler) getClusterVersion(ctx context.Context) (s
 		return "4.99.99", nil
 	}
 
-	clusterVersion := &configv1.ClusterVersion{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion)
+	clusterVersion, err := r.platform().Version(ctx, r.Client)
 	if err != nil {
-		log.Error(err, "Failed to detect clusterversion")
+		log.Error(err, "Failed to detect cluster version")
 		return "", err
 	}
+	return clusterVersion, nil
+}
 
-	if len(clusterVersion.Status.History) == 0 {
-		log.Error(err, "Failed to detect status in clusterversion.status.history")
-		return "", err
+//+kubebuilder:rbac:groups="config.openshift.io",resources="proxies",verbs=get;list;watch
+
+// setClusterProxy stores the cluster-wide proxy in env vars, so that charts can render it when the
+// operator has no proxy env vars of its own
+func (r *MultiClusterEngineReconciler) setClusterProxy(ctx context.Context) error {
+	if err := utils.SetClusterProxy(ctx, r.Client, r.platform().Name()); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to detect cluster proxy")
+		return err
 	}
-	return clusterVersion.Status.History[0].Version, nil
+	return nil
 }
 
 //+kubebuilder:rbac:groups="config.openshift.io",resources="ingresses",verbs=get;list;watch
@@ -1188,16 +1350,10 @@ func (r *MultiClusterEngineReconciler) getClusterIngressDomain(ctx context.Conte
 		return "apps.installer-test-cluster.dev00.red-chesterfield.com", nil
 	}
 
-	clusterIngress := &configv1.Ingress{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, clusterIngress)
+	domain, err := r.platform().IngressDomain(ctx, r.Client)
 	if err != nil {
 		log.Error(err, "Failed to detect cluster ingress")
 		return "", err
 	}
-
-	if clusterIngress.Spec.Domain == "" {
-		log.Error(err, "Domain not found or empty in Ingress")
-		return "", fmt.Errorf("Domain not found or empty in Ingress")
-	}
-	return clusterIngress.Spec.Domain, nil
+	return domain, nil
 }
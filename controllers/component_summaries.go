@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"encoding/json"
+	"sync"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/manifests"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+)
+
+// componentSummaries caches the summary of each component rendered for a MultiClusterEngine. Reconciles
+// requeue until the MultiClusterEngine is available, so a component is only rendered again for its
+// summary when the MultiClusterEngine or the render environment changed.
+type componentSummaries struct {
+	mu      sync.Mutex
+	entries map[string]*componentSummariesEntry
+}
+
+type componentSummariesEntry struct {
+	// inputs identifies what the summaries were rendered from
+	inputs    string
+	summaries map[string]manifests.ComponentSummary
+}
+
+func newComponentSummaries() *componentSummaries {
+	return &componentSummaries{entries: map[string]*componentSummariesEntry{}}
+}
+
+// get returns the summary of component for the MultiClusterEngine. Summaries are not cached on a nil
+// cache.
+func (c *componentSummaries) get(mce *backplanev1.MultiClusterEngine, component string) (manifests.ComponentSummary, error) {
+	if c == nil {
+		return manifests.SummarizeComponent(mce, component)
+	}
+	inputs, err := summaryInputs(mce)
+	if err != nil {
+		return manifests.ComponentSummary{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.entries[mce.Name]
+	if entry == nil || entry.inputs != inputs {
+		entry = &componentSummariesEntry{inputs: inputs, summaries: map[string]manifests.ComponentSummary{}}
+		c.entries[mce.Name] = entry
+	}
+	if summary, ok := entry.summaries[component]; ok {
+		return summary, nil
+	}
+	summary, err := manifests.SummarizeComponent(mce, component)
+	if err != nil {
+		return summary, err
+	}
+	entry.summaries[component] = summary
+	return summary, nil
+}
+
+// forget drops the summaries of a deleted MultiClusterEngine
+func (c *componentSummaries) forget(name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, name)
+}
+
+// summaryInputs identifies everything a component summary is rendered from besides the charts. The
+// images are not part of it, as summaries are rendered with placeholder images.
+func summaryInputs(mce *backplanev1.MultiClusterEngine) (string, error) {
+	inputs, err := json.Marshal(struct {
+		Generation  int64
+		Spec        backplanev1.MultiClusterEngineSpec
+		Labels      map[string]string
+		Annotations map[string]string
+		Env         map[string]string
+	}{mce.Generation, mce.Spec, mce.Labels, mce.Annotations, renderer.Environment()})
+	return string(inputs), err
+}
@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/manifests"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_componentSummaries(t *testing.T) {
+	t.Setenv("DIRECTORY_OVERRIDE", "../")
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.14.0")
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: DestinationNamespace},
+	}
+	c := newComponentSummaries()
+
+	want := manifests.ComponentSummary{ImageKeys: []string{"discovery_operator"}, Namespaces: []string{DestinationNamespace}}
+	got, err := c.get(mce, backplanev1.Discovery)
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("get() = %v, want %v", got, want)
+	}
+
+	// Unchanged inputs are served from the cache
+	cached := manifests.ComponentSummary{ImageKeys: []string{"cached"}}
+	c.entries[mce.Name].summaries[backplanev1.Discovery] = cached
+	if got, _ := c.get(mce, backplanev1.Discovery); !reflect.DeepEqual(got, cached) {
+		t.Errorf("get() = %v, want the cached %v", got, cached)
+	}
+
+	// Changing the MultiClusterEngine renders the component again
+	mce.SetAnnotations(map[string]string{"example.com/changed": "true"})
+	if got, _ := c.get(mce, backplanev1.Discovery); !reflect.DeepEqual(got, want) {
+		t.Errorf("get() = %v, want %v", got, want)
+	}
+
+	// So does changing the render environment
+	c.entries[mce.Name].summaries[backplanev1.Discovery] = cached
+	t.Setenv("DIRECTORY_OVERRIDE", "/nonexistent/")
+	if _, err := c.get(mce, backplanev1.Discovery); err == nil {
+		t.Error("expected rendering from a missing chart directory to fail")
+	}
+
+	c.forget(mce.Name)
+	if _, ok := c.entries[mce.Name]; ok {
+		t.Error("expected the summaries of the MultiClusterEngine to be forgotten")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// podConfig is the config consumed by component pods that is not part of their rendered spec
+type podConfig struct {
+	Proxy       map[string]string            `json:"proxy,omitempty"`
+	TrustBundle map[string]string            `json:"trustBundle,omitempty"`
+	PullSecrets map[string]map[string][]byte `json:"pullSecrets,omitempty"`
+}
+
+// trustBundleName returns the name of the configmap injected with the trusted CA bundle
+func trustBundleName() string {
+	if name, ok := os.LookupEnv(trustBundleNameEnvVar); ok && name != "" {
+		return name
+	}
+	return defaultTrustBundleName
+}
+
+// computeConfigHash hashes the proxy settings, trusted CA bundle and image pull secrets. Missing
+// configmaps and secrets hash as empty.
+func (r *MultiClusterEngineReconciler) computeConfigHash(ctx context.Context, mce *backplanev1.MultiClusterEngine) (string, error) {
+	config := podConfig{Proxy: utils.ProxyConfigs()}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: trustBundleName(), Namespace: mce.Spec.TargetNamespace}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("error getting trust bundle configmap: %w", err)
+	}
+	config.TrustBundle = cm.Data
+
+	config.PullSecrets = map[string]map[string][]byte{}
+	for _, name := range mce.PullSecrets() {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: mce.Spec.TargetNamespace}, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("error getting image pull secret %s: %w", name, err)
+		}
+		config.PullSecrets[name] = secret.Data
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// setConfigHash annotates the pod template of a Deployment with the config hash
+func setConfigHash(template *unstructured.Unstructured, hash string) error {
+	if template.GetKind() != "Deployment" || hash == "" {
+		return nil
+	}
+	return unstructured.SetNestedField(template.Object, hash, "spec", "template", "metadata", "annotations", utils.AnnotationConfigHash)
+}
+
+// trustBundleToMultiClusterEngines maps a trust bundle configmap to the MultiClusterEngines deployed in its namespace
+func (r *MultiClusterEngineReconciler) trustBundleToMultiClusterEngines(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != trustBundleName() {
+		return nil
+	}
+	return r.multiClusterEnginesMatching(ctx, func(mce backplanev1.MultiClusterEngine) bool {
+		return mce.Spec.TargetNamespace == obj.GetNamespace()
+	})
+}
+
+// pullSecretToMultiClusterEngines maps an image pull secret to the MultiClusterEngines that use it
+func (r *MultiClusterEngineReconciler) pullSecretToMultiClusterEngines(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.multiClusterEnginesMatching(ctx, func(mce backplanev1.MultiClusterEngine) bool {
+		return mce.Spec.TargetNamespace == obj.GetNamespace() && utils.Contains(mce.PullSecrets(), obj.GetName())
+	})
+}
+
+func (r *MultiClusterEngineReconciler) multiClusterEnginesMatching(ctx context.Context, match func(backplanev1.MultiClusterEngine) bool) []reconcile.Request {
+	mceList := &backplanev1.MultiClusterEngineList{}
+	if err := r.Client.List(ctx, mceList); err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, mce := range mceList.Items {
+		if match(mce) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: mce.GetName()}})
+		}
+	}
+	return requests
+}
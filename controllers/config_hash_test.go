@@ -0,0 +1,123 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_computeConfigHash(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: DestinationNamespace,
+			ImagePullSecret: "pull-secret",
+		},
+	}
+	bundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: defaultTrustBundleName, Namespace: DestinationNamespace},
+		Data:       map[string]string{"ca-bundle.crt": "old"},
+	}
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: DestinationNamespace},
+		Data:       map[string][]byte{".dockerconfigjson": []byte("old")},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(bundle, pullSecret).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme}
+	ctx := context.TODO()
+
+	hash, err := r.computeConfigHash(ctx, mce)
+	if err != nil {
+		t.Fatalf("computeConfigHash() error = %v", err)
+	}
+	if again, _ := r.computeConfigHash(ctx, mce); again != hash {
+		t.Errorf("computeConfigHash() is not stable: %s != %s", again, hash)
+	}
+
+	bundle.Data["ca-bundle.crt"] = "new"
+	if err := cl.Update(ctx, bundle); err != nil {
+		t.Fatal(err)
+	}
+	bundleHash, _ := r.computeConfigHash(ctx, mce)
+	if bundleHash == hash {
+		t.Errorf("computeConfigHash() did not change with the trust bundle")
+	}
+
+	pullSecret.Data[".dockerconfigjson"] = []byte("new")
+	if err := cl.Update(ctx, pullSecret); err != nil {
+		t.Fatal(err)
+	}
+	secretHash, _ := r.computeConfigHash(ctx, mce)
+	if secretHash == bundleHash {
+		t.Errorf("computeConfigHash() did not change with the pull secret")
+	}
+
+	t.Setenv("HTTP_PROXY", "http://proxy:3128")
+	proxyHash, _ := r.computeConfigHash(ctx, mce)
+	if proxyHash == secretHash {
+		t.Errorf("computeConfigHash() did not change with the proxy")
+	}
+}
+
+func Test_hostedReconcileConfigHash(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        BackplaneConfigName,
+			Annotations: map[string]string{"deploymentmode": string(backplanev1.ModeHosted)},
+			Finalizers:  []string{backplaneFinalizer},
+		},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace:    DestinationNamespace,
+			AvailabilityConfig: backplanev1.HAHigh,
+		},
+	}
+	utils.SetHostedDefaultComponents(mce)
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: DestinationNamespace}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mce, ns).WithStatusSubresource(mce).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme, StatusManager: &status.StatusTracker{Client: cl}}
+
+	// The reconcile stops at the missing images, after the config hash is computed
+	r.HostedReconcile(context.TODO(), mce)
+	if r.configHash == "" {
+		t.Error("expected the config hash to be computed for a hosted MultiClusterEngine")
+	}
+}
+
+func Test_setConfigHash(t *testing.T) {
+	deployment := &unstructured.Unstructured{}
+	deployment.SetKind("Deployment")
+	service := &unstructured.Unstructured{}
+	service.SetKind("Service")
+
+	for _, u := range []*unstructured.Unstructured{deployment, service} {
+		if err := setConfigHash(u, "abc"); err != nil {
+			t.Fatalf("setConfigHash() error = %v", err)
+		}
+	}
+
+	got, _, _ := unstructured.NestedString(deployment.Object, "spec", "template", "metadata", "annotations", utils.AnnotationConfigHash)
+	if got != "abc" {
+		t.Errorf("setConfigHash() on Deployment = %q, want %q", got, "abc")
+	}
+	if _, found := service.Object["spec"]; found {
+		t.Errorf("setConfigHash() modified a Service")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"sync"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// configRef identifies a configmap or secret read by a MultiClusterEngine
+type configRef struct {
+	Kind string
+	types.NamespacedName
+}
+
+// configRefs keeps the configmaps and secrets each MultiClusterEngine read in its last reconcile. The
+// operator watches every configmap and secret in the cluster, and events for the ones no
+// MultiClusterEngine reads are dropped against this set before they are mapped to requests.
+type configRefs struct {
+	mu   sync.RWMutex
+	refs map[string]map[configRef]bool
+}
+
+func newConfigRefs() *configRefs {
+	return &configRefs{refs: map[string]map[configRef]bool{}}
+}
+
+// set replaces the configmaps and secrets read by the MultiClusterEngine
+func (c *configRefs) set(mce *backplanev1.MultiClusterEngine) {
+	if c == nil {
+		return
+	}
+	refs := map[configRef]bool{
+		{Kind: "ConfigMap", NamespacedName: types.NamespacedName{Name: trustBundleName(), Namespace: mce.Spec.TargetNamespace}}: true,
+	}
+	if name := utils.GetImageOverridesConfigmap(mce); name != "" {
+		refs[configRef{Kind: "ConfigMap", NamespacedName: types.NamespacedName{Name: name, Namespace: utils.OperatorNamespace()}}] = true
+	}
+	for _, name := range mce.PullSecrets() {
+		refs[configRef{Kind: "Secret", NamespacedName: types.NamespacedName{Name: name, Namespace: mce.Spec.TargetNamespace}}] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refs[mce.Name] = refs
+}
+
+// forget drops the configmaps and secrets read by a deleted MultiClusterEngine
+func (c *configRefs) forget(name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.refs, name)
+}
+
+// read returns true if a MultiClusterEngine reads the configmap or secret
+func (c *configRefs) read(kind string, obj client.Object) bool {
+	if c == nil {
+		return true
+	}
+	ref := configRef{Kind: kind, NamespacedName: types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, refs := range c.refs {
+		if refs[ref] {
+			return true
+		}
+	}
+	return false
+}
+
+// readBy returns a predicate that passes events for the configmaps or secrets a MultiClusterEngine reads
+func (c *configRefs) readBy(kind string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return c.read(kind, obj)
+	})
+}
+
+// TrimConfigMetadata is a cache transform for the metadata of the configmaps and secrets the operator
+// watches. Only their names and resource versions are used, so annotations and managed fields, which
+// can hold a copy of the whole object, are dropped to keep the cluster-wide watches small.
+func TrimConfigMetadata(obj interface{}) (interface{}, error) {
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		// Not an object, such as a tombstone of a deleted object
+		return obj, nil
+	}
+	accessor.SetAnnotations(nil)
+	accessor.SetManagedFields(nil)
+	return obj, nil
+}
@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_configRefs(t *testing.T) {
+	refs := newConfigRefs()
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: "mce-ns",
+			ImagePullSecret: "pull-secret",
+		},
+	}
+	secret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	configMap := func(namespace, name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	if refs.read("Secret", secret("mce-ns", "pull-secret")) {
+		t.Errorf("expected no secrets to be read before a reconcile")
+	}
+
+	refs.set(mce)
+	if !refs.read("Secret", secret("mce-ns", "pull-secret")) {
+		t.Errorf("expected the pull secret to be read")
+	}
+	if refs.read("Secret", secret("other-ns", "pull-secret")) {
+		t.Errorf("expected a secret of the same name in another namespace not to be read")
+	}
+	if refs.read("ConfigMap", configMap("mce-ns", "pull-secret")) {
+		t.Errorf("expected a configmap named like the pull secret not to be read")
+	}
+	if !refs.read("ConfigMap", configMap("mce-ns", trustBundleName())) {
+		t.Errorf("expected the trust bundle to be read")
+	}
+
+	mce.Spec.ImagePullSecret = ""
+	refs.set(mce)
+	if refs.read("Secret", secret("mce-ns", "pull-secret")) {
+		t.Errorf("expected a pull secret removed from the spec not to be read")
+	}
+
+	refs.forget(mce.Name)
+	if refs.read("ConfigMap", configMap("mce-ns", trustBundleName())) {
+		t.Errorf("expected nothing to be read after the MultiClusterEngine is forgotten")
+	}
+}
+
+func Test_TrimConfigMetadata(t *testing.T) {
+	obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+		Name:            "pull-secret",
+		ResourceVersion: "42",
+		Annotations:     map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+	}}
+	got, err := TrimConfigMetadata(obj)
+	if err != nil {
+		t.Fatalf("TrimConfigMetadata() error = %v", err)
+	}
+	trimmed := got.(*metav1.PartialObjectMetadata)
+	if trimmed.Annotations != nil || trimmed.ManagedFields != nil {
+		t.Errorf("expected annotations and managed fields to be dropped, got %+v", trimmed.ObjectMeta)
+	}
+	if trimmed.Name != "pull-secret" || trimmed.ResourceVersion != "42" {
+		t.Errorf("expected name and resource version to be kept, got %+v", trimmed.ObjectMeta)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/crds"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// CRDReconciler applies the CustomResourceDefinitions shipped with the operator and
+// repairs them if they are modified or deleted
+type CRDReconciler struct {
+	client.Client
+	// CRDs rendered from the operator's CRD directory, keyed by name
+	CRDs map[string]*unstructured.Unstructured
+	// Removable are the names of the CRDs removed on uninstall when the uninstall policy deletes CRDs
+	Removable map[string]bool
+}
+
+// Reconcile server-side applies the desired CRD matching the request name
+func (r *CRDReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	desired, ok := r.CRDs[req.Name]
+	if !ok {
+		// Not a CRD managed by the operator
+		return ctrl.Result{}, nil
+	}
+
+	exists, removing, err := r.crdRemovalState(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if removing && r.Removable[req.Name] {
+		// The MultiClusterEngine is being uninstalled with its CRDs. Do not recreate them.
+		return ctrl.Result{}, nil
+	}
+
+	existing := &apixv1.CustomResourceDefinition{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: req.Name}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("error getting CRD '%s': %w", req.Name, err)
+	}
+	if apierrors.IsNotFound(err) && !exists && r.Removable[req.Name] {
+		// The CRD may have been removed with the last MultiClusterEngine. It is created again along with
+		// the next MultiClusterEngine.
+		log.Info(fmt.Sprintf("No MultiClusterEngine exists. Skipping creation of CRD '%s'.", req.Name))
+		return ctrl.Result{}, nil
+	}
+	if err == nil {
+		if utils.AnnotationPresent(utils.AnnotationMCEIgnore, existing) {
+			log.Info(fmt.Sprintf("CRD '%s' has ignore label. Skipping update.", req.Name))
+			return ctrl.Result{}, nil
+		}
+
+		compat, err := crds.CheckCompatibility(existing, desired)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for _, w := range compat.Warnings() {
+			log.Info(fmt.Sprintf("CRD '%s': %s", req.Name, w))
+		}
+		if !compat.Safe() {
+			// Objects may still be stored in a version the new CRD drops. Apply the new CRD with those
+			// versions kept served, so the objects can be migrated to the new storage version, and apply
+			// the new CRD once the migration has pruned them from the stored versions.
+			log.Info(fmt.Sprintf("Migrating stored versions of CRD '%s' before updating it", req.Name))
+			intermediate, err := crds.IntermediateCRD(existing, desired)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.applyCRD(ctx, intermediate); err != nil {
+				return ctrl.Result{}, err
+			}
+			if _, err := r.migrateStorageVersion(ctx, req.Name); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+		}
+	}
+
+	if err := r.applyCRD(ctx, desired); err != nil {
+		return ctrl.Result{}, err
+	}
+	return r.migrateStorageVersion(ctx, req.Name)
+}
+
+// applyCRD server-side applies a copy of the CRD
+func (r *CRDReconciler) applyCRD(ctx context.Context, desired *unstructured.Unstructured) error {
+	crd := desired.DeepCopy()
+	force := true
+	err := r.Client.Patch(ctx, crd, client.Apply, &client.PatchOptions{Force: &force, FieldManager: "backplane-operator"})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to apply CRD", "Name", crd.GetName())
+		return fmt.Errorf("error applying CRD '%s': %w", crd.GetName(), err)
+	}
+	return nil
+}
+
+// migrateStorageVersion rewrites objects of the named CRD in its storage version using a
+// StorageVersionMigration, then prunes the old versions from status.storedVersions. The migration
+// is deleted once the versions are pruned, as a later change back to the same storage version must
+// migrate the objects again rather than reuse it.
+func (r *CRDReconciler) migrateStorageVersion(ctx context.Context, name string) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	crd := &apixv1.CustomResourceDefinition{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting CRD '%s': %w", name, err)
+	}
+	if !crds.NeedsMigration(crd) {
+		return ctrl.Result{}, nil
+	}
+
+	migration := &unstructured.Unstructured{}
+	migration.SetGroupVersionKind(crds.MigrationGVK)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: crds.MigrationName(crd)}, migration)
+	if apimeta.IsNoMatchError(err) {
+		log.Info(fmt.Sprintf("StorageVersionMigration API unavailable. Stored versions of CRD '%s' will not be pruned.", name))
+		return ctrl.Result{}, nil
+	}
+	if apierrors.IsNotFound(err) {
+		log.Info(fmt.Sprintf("Migrating stored versions %v of CRD '%s'", crd.Status.StoredVersions, name))
+		if err := r.Client.Create(ctx, crds.NewStorageVersionMigration(crd)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating StorageVersionMigration for CRD '%s': %w", name, err)
+		}
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting StorageVersionMigration for CRD '%s': %w", name, err)
+	}
+
+	switch crds.GetMigrationState(migration) {
+	case crds.MigrationSucceeded:
+		crd.Status.StoredVersions = []string{crds.StorageVersion(crd)}
+		if err := r.Client.Status().Update(ctx, crd); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error pruning stored versions of CRD '%s': %w", name, err)
+		}
+		log.Info(fmt.Sprintf("Pruned stored versions of CRD '%s'", name))
+		if err := r.Client.Delete(ctx, migration); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error deleting StorageVersionMigration for CRD '%s': %w", name, err)
+		}
+		return ctrl.Result{}, nil
+	case crds.MigrationFailed:
+		// Remove the failed migration so it is recreated on the next attempt
+		if err := r.Client.Delete(ctx, migration); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error deleting failed StorageVersionMigration for CRD '%s': %w", name, err)
+		}
+		return ctrl.Result{}, fmt.Errorf("storage version migration of CRD '%s' failed", name)
+	default:
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+}
+
+// DesiredCRDs returns the CRDs the reconciler applies, sorted by name
+func (r *CRDReconciler) DesiredCRDs() []*unstructured.Unstructured {
+	names := []string{}
+	for name := range r.CRDs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	desired := []*unstructured.Unstructured{}
+	for _, name := range names {
+		desired = append(desired, r.CRDs[name])
+	}
+	return desired
+}
+
+// crdRemovalState returns whether any MultiClusterEngine exists, and whether one that removes CRDs on
+// uninstall is being deleted
+func (r *CRDReconciler) crdRemovalState(ctx context.Context) (exists, removing bool, err error) {
+	mceList := &backplanev1.MultiClusterEngineList{}
+	if err := r.Client.List(ctx, mceList); err != nil {
+		return false, false, fmt.Errorf("error listing MultiClusterEngines: %w", err)
+	}
+	for _, mce := range mceList.Items {
+		if mce.GetDeletionTimestamp() != nil && mce.ShouldDeleteCRDs() {
+			removing = true
+		}
+	}
+	return len(mceList.Items) > 0, removing, nil
+}
+
+// SetupWithManager renders the CRDs from crdDir and sets up the controller with the Manager.
+func (r *CRDReconciler) SetupWithManager(mgr ctrl.Manager, crdDir string) error {
+	rendered, errs := renderer.RenderCRDs(crdDir)
+	if len(errs) > 0 {
+		return fmt.Errorf("error rendering CRDs: %v", errs)
+	}
+	r.CRDs = map[string]*unstructured.Unstructured{}
+	for _, crd := range rendered {
+		r.CRDs[crd.GetName()] = crd
+	}
+	removable, err := removableCRDs(crdDir)
+	if err != nil {
+		return err
+	}
+	r.Removable = removable
+
+	// Queue every CRD once on startup, so that missing CRDs are created
+	// even though no watch event exists for them
+	initialSync := make(chan event.GenericEvent, len(r.CRDs))
+	for name := range r.CRDs {
+		initialSync <- event.GenericEvent{
+			Object: &apixv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}},
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("crd").
+		For(&apixv1.CustomResourceDefinition{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(o client.Object) bool {
+				_, ok := r.CRDs[o.GetName()]
+				return ok
+			}),
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)).
+		WatchesRawSource(&source.Channel{Source: initialSync}, &handler.EnqueueRequestForObject{}).
+		// Reinstall CRDs removed by a previous uninstall when a MultiClusterEngine is created
+		Watches(&backplanev1.MultiClusterEngine{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				requests := []reconcile.Request{}
+				for name := range r.CRDs {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+				}
+				return requests
+			}), builder.WithPredicates(predicate.Funcs{
+			CreateFunc:  func(e event.CreateEvent) bool { return true },
+			UpdateFunc:  func(e event.UpdateEvent) bool { return false },
+			DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+			GenericFunc: func(e event.GenericEvent) bool { return false },
+		})).
+		Complete(r)
+}
@@ -0,0 +1,124 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/crds"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+)
+
+func Test_removableCRDs(t *testing.T) {
+	os.Setenv("DIRECTORY_OVERRIDE", "../")
+	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+
+	removable, err := removableCRDs(renderer.CRDsDir)
+	if err != nil {
+		t.Fatalf("removableCRDs() error = %v", err)
+	}
+	if !removable["clusterdeployments.hive.openshift.io"] {
+		t.Errorf("expected component CRD clusterdeployments.hive.openshift.io to be removable")
+	}
+	for name := range operatorCRDs {
+		if removable[name] {
+			t.Errorf("expected CRD %s watched by the operator to be retained", name)
+		}
+	}
+}
+
+func Test_CRDReconcilerWithoutMultiClusterEngine(t *testing.T) {
+	name := "clusterdeployments.hive.openshift.io"
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(name)
+
+	scheme := runtime.NewScheme()
+	apixv1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &CRDReconciler{
+		Client:    cl,
+		CRDs:      map[string]*unstructured.Unstructured{name: crd},
+		Removable: map[string]bool{name: true},
+	}
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: name}, &apixv1.CustomResourceDefinition{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected removed component CRD not to be recreated without a MultiClusterEngine, got %v", err)
+	}
+}
+
+func Test_migrateStorageVersion(t *testing.T) {
+	name := "clusterdeployments.hive.openshift.io"
+	crd := &apixv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apixv1.CustomResourceDefinitionSpec{
+			Group: "hive.openshift.io",
+			Names: apixv1.CustomResourceDefinitionNames{Plural: "clusterdeployments"},
+			Versions: []apixv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true},
+				{Name: "v2", Served: true, Storage: true},
+			},
+		},
+		Status: apixv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1", "v2"}},
+	}
+	migration := crds.NewStorageVersionMigration(crd)
+	migration.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": string(crds.MigrationSucceeded), "status": "True"}},
+	}
+
+	scheme := runtime.NewScheme()
+	apixv1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd, migration).WithStatusSubresource(crd).Build()
+	r := &CRDReconciler{Client: cl}
+	ctx := context.TODO()
+
+	if _, err := r.migrateStorageVersion(ctx, name); err != nil {
+		t.Fatalf("migrateStorageVersion() error = %v", err)
+	}
+	got := &apixv1.CustomResourceDefinition{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: name}, got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v2"}; !reflect.DeepEqual(got.Status.StoredVersions, want) {
+		t.Errorf("stored versions = %v, want %v", got.Status.StoredVersions, want)
+	}
+	err := cl.Get(ctx, types.NamespacedName{Name: migration.GetName()}, migration.DeepCopy())
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the succeeded StorageVersionMigration to be deleted, got %v", err)
+	}
+}
+
+func Test_DesiredCRDs(t *testing.T) {
+	r := &CRDReconciler{CRDs: map[string]*unstructured.Unstructured{}}
+	for _, name := range []string{"b.test.io", "a.test.io"} {
+		crd := &unstructured.Unstructured{}
+		crd.SetName(name)
+		r.CRDs[name] = crd
+	}
+
+	got := r.DesiredCRDs()
+	if len(got) != 2 || got[0].GetName() != "a.test.io" || got[1].GetName() != "b.test.io" {
+		t.Errorf("DesiredCRDs() = %v, want the CRDs sorted by name", got)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events recorded on the MultiClusterEngine
+const (
+	componentEnabledEvent      = "ComponentEnabled"
+	componentDisabledEvent     = "ComponentDisabled"
+	componentAvailableEvent    = "ComponentAvailable"
+	componentUnavailableEvent  = "ComponentUnavailable"
+	pausedEvent                = "Paused"
+	resumedEvent               = "Resumed"
+	upgradeStartedEvent        = "UpgradeStarted"
+	upgradeCompletedEvent      = "UpgradeCompleted"
+	finalizeStepCompletedEvent = "FinalizeStepCompleted"
+	finalizedEvent             = "Finalized"
+)
+
+// transitionRecorder records events on a MultiClusterEngine when the state it observes changes. The
+// states last observed are kept per MultiClusterEngine so reconciles that change nothing record no events.
+type transitionRecorder struct {
+	recorder record.EventRecorder
+
+	mu     sync.Mutex
+	states map[string]map[string]string
+}
+
+func newTransitionRecorder(recorder record.EventRecorder) *transitionRecorder {
+	return &transitionRecorder{recorder: recorder, states: map[string]map[string]string{}}
+}
+
+// transition records the state under key for the MultiClusterEngine and returns the previous state and
+// whether it changed. When no state has been recorded yet, such as after a restart, the previous state
+// is initial.
+func (t *transitionRecorder) transition(mce *backplanev1.MultiClusterEngine, key, state, initial string) (string, bool) {
+	if t == nil {
+		return initial, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	states, ok := t.states[mce.Name]
+	if !ok {
+		states = map[string]string{}
+		t.states[mce.Name] = states
+	}
+	previous, ok := states[key]
+	if !ok {
+		previous = initial
+	}
+	states[key] = state
+	return previous, previous != state
+}
+
+// event records an event on the MultiClusterEngine
+func (t *transitionRecorder) event(mce *backplanev1.MultiClusterEngine, eventtype, reason, message string) {
+	if t == nil || t.recorder == nil {
+		return
+	}
+	t.recorder.Event(mce, eventtype, reason, message)
+}
+
+// once records an event the first time key is observed for the MultiClusterEngine
+func (t *transitionRecorder) once(mce *backplanev1.MultiClusterEngine, key, eventtype, reason, message string) {
+	if _, changed := t.transition(mce, key, "done", ""); changed {
+		t.event(mce, eventtype, reason, message)
+	}
+}
+
+// forget drops the states observed for a MultiClusterEngine that no longer exists
+func (t *transitionRecorder) forget(name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.states, name)
+}
+
+// recordTransitions records events for the changes between the reported status of the MultiClusterEngine
+// and the previous one: components enabled, disabled, becoming available or unavailable, the
+// MultiClusterEngine being paused or resumed, and upgrades starting and completing
+func (r *MultiClusterEngineReconciler) recordTransitions(mce *backplanev1.MultiClusterEngine, previous backplanev1.MultiClusterEngineStatus) {
+	t := r.events
+
+	if mce.Spec.Overrides != nil {
+		for _, c := range mce.Spec.Overrides.Components {
+			enabled := strconv.FormatBool(c.Enabled)
+			if _, changed := t.transition(mce, "enabled/"+c.Name, enabled, enabled); !changed {
+				continue
+			}
+			if c.Enabled {
+				t.event(mce, corev1.EventTypeNormal, componentEnabledEvent, fmt.Sprintf("Component %s was enabled", c.Name))
+			} else {
+				t.event(mce, corev1.EventTypeNormal, componentDisabledEvent, fmt.Sprintf("Component %s was disabled", c.Name))
+			}
+		}
+	}
+
+	for _, c := range mce.Status.Components {
+		available := strconv.FormatBool(c.Available)
+		if _, changed := t.transition(mce, "available/"+c.Kind+"/"+c.Name, available, available); !changed {
+			continue
+		}
+		if c.Available {
+			t.event(mce, corev1.EventTypeNormal, componentAvailableEvent, fmt.Sprintf("%s %s is available", c.Kind, c.Name))
+		} else {
+			t.event(mce, corev1.EventTypeWarning, componentUnavailableEvent, fmt.Sprintf("%s %s is unavailable: %s", c.Kind, c.Name, c.Message))
+		}
+	}
+
+	paused := strconv.FormatBool(utils.IsPaused(mce))
+	if _, changed := t.transition(mce, "paused", paused, "false"); changed {
+		if utils.IsPaused(mce) {
+			t.event(mce, corev1.EventTypeNormal, pausedEvent, "Reconciliation is paused")
+		} else {
+			t.event(mce, corev1.EventTypeNormal, resumedEvent, "Reconciliation is resumed")
+		}
+	}
+
+	// The version being upgraded to, or empty when not upgrading. The previous status seeds the state
+	// so the upgrade started by a new version of the operator is recorded after it restarts.
+	if from, changed := t.transition(mce, "upgrade", upgradeTarget(mce.Status), upgradeTarget(previous)); changed {
+		if to := upgradeTarget(mce.Status); to != "" {
+			t.event(mce, corev1.EventTypeNormal, upgradeStartedEvent, fmt.Sprintf("Upgrading from %s to %s", mce.Status.CurrentVersion, to))
+		} else if mce.Status.CurrentVersion == from {
+			t.event(mce, corev1.EventTypeNormal, upgradeCompletedEvent, fmt.Sprintf("Upgraded to %s", from))
+		}
+	}
+}
+
+// upgradeTarget returns the version being upgraded to, or empty when the status is not upgrading. A
+// first install is not an upgrade.
+func upgradeTarget(s backplanev1.MultiClusterEngineStatus) string {
+	if s.CurrentVersion == "" || s.CurrentVersion == s.DesiredVersion {
+		return ""
+	}
+	return s.DesiredVersion
+}
@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_recordTransitions(t *testing.T) {
+	recorder := record.NewFakeRecorder(20)
+	r := &MultiClusterEngineReconciler{events: newTransitionRecorder(recorder)}
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{{Name: backplanev1.Discovery, Enabled: true}},
+			},
+		},
+		Status: backplanev1.MultiClusterEngineStatus{
+			CurrentVersion: "2.4.0",
+			DesiredVersion: "2.4.0",
+			Components: []backplanev1.ComponentCondition{
+				{Name: "discovery-operator", Kind: "Deployment", Available: true},
+			},
+		},
+	}
+
+	// reconcile records the status of mce as reported after the previous status
+	reconcile := func(previous backplanev1.MultiClusterEngineStatus) []string {
+		r.recordTransitions(mce, previous)
+		events := []string{}
+		for {
+			select {
+			case e := <-recorder.Events:
+				events = append(events, e)
+			default:
+				return events
+			}
+		}
+	}
+	expectEvents := func(t *testing.T, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+		for i := range want {
+			if !strings.HasPrefix(got[i], want[i]) {
+				t.Errorf("expected event %q, got %q", want[i], got[i])
+			}
+		}
+	}
+
+	t.Run("first and steady state reconciles record nothing", func(t *testing.T) {
+		expectEvents(t, reconcile(mce.Status))
+		expectEvents(t, reconcile(mce.Status))
+	})
+
+	t.Run("component becomes unavailable", func(t *testing.T) {
+		mce.Status.Components[0].Available = false
+		mce.Status.Components[0].Message = "Deployment does not have minimum availability"
+		expectEvents(t, reconcile(mce.Status), "Warning ComponentUnavailable Deployment discovery-operator is unavailable")
+		expectEvents(t, reconcile(mce.Status))
+
+		mce.Status.Components[0].Available = true
+		expectEvents(t, reconcile(mce.Status), "Normal ComponentAvailable Deployment discovery-operator is available")
+	})
+
+	t.Run("component disabled", func(t *testing.T) {
+		mce.Spec.Overrides.Components[0].Enabled = false
+		expectEvents(t, reconcile(mce.Status), "Normal ComponentDisabled Component discovery was disabled")
+		expectEvents(t, reconcile(mce.Status))
+	})
+
+	t.Run("paused and resumed", func(t *testing.T) {
+		mce.SetAnnotations(map[string]string{utils.AnnotationMCEPause: "true"})
+		expectEvents(t, reconcile(mce.Status), "Normal Paused")
+		expectEvents(t, reconcile(mce.Status))
+		mce.SetAnnotations(nil)
+		expectEvents(t, reconcile(mce.Status), "Normal Resumed")
+	})
+
+	t.Run("upgrade after the operator restarts", func(t *testing.T) {
+		r.events = newTransitionRecorder(recorder)
+		previous := mce.Status
+		mce.Status.DesiredVersion = "2.5.0"
+		expectEvents(t, reconcile(previous), "Normal UpgradeStarted Upgrading from 2.4.0 to 2.5.0")
+		expectEvents(t, reconcile(mce.Status))
+
+		mce.Status.CurrentVersion = "2.5.0"
+		expectEvents(t, reconcile(mce.Status), "Normal UpgradeCompleted Upgraded to 2.5.0")
+	})
+
+	t.Run("finalize steps are recorded once", func(t *testing.T) {
+		r.finalizeStepCompleted(mce, "ClusterManager cluster-manager")
+		r.finalizeStepCompleted(mce, "ClusterManager cluster-manager")
+		expectEvents(t, reconcile(mce.Status), "Normal FinalizeStepCompleted ClusterManager cluster-manager was removed")
+
+		r.events.forget(mce.Name)
+		r.finalizeStepCompleted(mce, "ClusterManager cluster-manager")
+		expectEvents(t, reconcile(mce.Status), "Normal FinalizeStepCompleted")
+	})
+}
+
+func Test_recordTransitionsWithoutRecorder(t *testing.T) {
+	r := &MultiClusterEngineReconciler{}
+	mce := &backplanev1.MultiClusterEngine{ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName}}
+	r.recordTransitions(mce, mce.Status)
+	r.finalizeStepCompleted(mce, "ClusterManager cluster-manager")
+	r.events.forget(mce.Name)
+}
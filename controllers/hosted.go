@@ -12,6 +12,7 @@ import (
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/pkg/foundation"
 	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/metrics"
 	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/toggle"
@@ -35,7 +36,10 @@ func (r *MultiClusterEngineReconciler) HostedReconcile(ctx context.Context, mce
 	log := log.FromContext(ctx)
 
 	defer func() {
+		previous := mce.Status
 		mce.Status = r.StatusManager.ReportStatus(*mce)
+		metrics.ReportStatus(mce)
+		r.recordTransitions(mce, previous)
 		err := r.Client.Status().Update(ctx, mce)
 		if mce.Status.Phase != backplanev1.MultiClusterEnginePhaseAvailable && !utils.IsPaused(mce) {
 			retRes = ctrl.Result{RequeueAfter: requeuePeriod}
@@ -99,8 +103,13 @@ func (r *MultiClusterEngineReconciler) HostedReconcile(ctx context.Context, mce
 		return ctrl.Result{Requeue: true}, err
 	}
 
+	r.configHash, err = r.computeConfigHash(ctx, mce)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
 	// Read images from environmental variables
-	imgs, err := images.GetImagesWithOverrides(r.Client, mce)
+	imgs, overrideIssues, err := images.GetImagesWithOverrides(r.Client, mce)
 	if err != nil {
 		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionFalse, status.RequirementsNotMetReason, fmt.Sprintf("Issue building image references: %s", err.Error())))
 		return ctrl.Result{}, err
@@ -110,6 +119,11 @@ func (r *MultiClusterEngineReconciler) HostedReconcile(ctx context.Context, mce
 		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionFalse, status.RequirementsNotMetReason, "No image references defined in deployment"))
 		return ctrl.Result{RequeueAfter: requeuePeriod}, errors.New("no image references exist. images must be defined as environment variables")
 	}
+	r.setImageOverridesCondition(mce, overrideIssues)
+	imgs, err = r.resolveImageMirrors(ctx, mce, imgs)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
 	r.Images = imgs
 
 	// Do not reconcile objects if this instance of mce is labeled "paused"
@@ -119,6 +133,10 @@ func (r *MultiClusterEngineReconciler) HostedReconcile(ctx context.Context, mce
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.setPullSecretNamespaces(ctx, mce); err != nil {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, err
+	}
+
 	hostedClient, err := r.GetHostedClient(ctx, mce)
 	if err != nil {
 		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionFalse, status.RequirementsNotMetReason, fmt.Sprintf("couldn't connect to hosted environment: %s", err.Error())))
@@ -176,6 +194,10 @@ func (r *MultiClusterEngineReconciler) HostedReconcile(ctx context.Context, mce
 		}
 	}
 
+	if err := r.prunePullSecrets(ctx, mce); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionTrue, status.DeploySuccessReason, "All components deployed"))
 	return ctrl.Result{}, nil
 }
@@ -334,6 +356,11 @@ func (r *MultiClusterEngineReconciler) ensureHostedClusterManager(ctx context.Co
 		return ctrl.Result{Requeue: true}, err
 	}
 
+	// Copy image pull secrets into namespace
+	if err := r.ensurePullSecrets(ctx, mce, cmName); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+
 	// Apply secret in namespace
 	kubeconfigSecret := &corev1.Secret{}
 	secretNN, err := utils.GetHostedCredentialsSecret(mce)
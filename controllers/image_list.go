@@ -0,0 +1,107 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/manifests"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// imageListName is the configmap listing the images deployed by the enabled components
+	imageListName = "multicluster-engine-images"
+	// imageListComponentsKey holds a JSON object mapping each enabled component to its images
+	imageListComponentsKey = "components.json"
+	// imageListImagesKey holds every image, one per line
+	imageListImagesKey = "images.txt"
+)
+
+// enabledComponents returns the sorted components that are deployed from a chart for this MultiClusterEngine
+func (r *MultiClusterEngineReconciler) enabledComponents(ctx context.Context, mce *backplanev1.MultiClusterEngine) ([]string, error) {
+	components := []string{}
+	for component := range toggle.ComponentCharts {
+		if !mce.Enabled(component) {
+			continue
+		}
+		if component == backplanev1.ConsoleMCE {
+			ocpConsole, err := r.CheckConsole(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if !ocpConsole {
+				continue
+			}
+		}
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	return components, nil
+}
+
+// ensureImageList keeps a configmap in the target namespace listing the images the enabled
+// components deploy, so they can be mirrored for disconnected installs. The images come from the
+// cached component summaries, so components are only rendered again when the MultiClusterEngine changes.
+func (r *MultiClusterEngineReconciler) ensureImageList(ctx context.Context, mce *backplanev1.MultiClusterEngine) error {
+	components, err := r.enabledComponents(ctx, mce)
+	if err != nil {
+		return err
+	}
+	summaries := map[string]manifests.ComponentSummary{}
+	for _, component := range components {
+		summary, err := r.summaries.get(mce, component)
+		if err != nil {
+			return err
+		}
+		summaries[component] = summary
+	}
+	list := manifests.ComponentImages(summaries, r.Images)
+	componentsJSON, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	data := map[string]string{
+		imageListComponentsKey: string(componentsJSON),
+		imageListImagesKey:     strings.Join(images.UniqueImages(list), "\n") + "\n",
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: imageListName, Namespace: mce.Spec.TargetNamespace}, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error getting image list configmap: %w", err)
+	}
+	if err == nil {
+		if reflect.DeepEqual(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return r.Client.Update(ctx, cm)
+	}
+
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      imageListName,
+			Namespace: mce.Spec.TargetNamespace,
+		},
+		Data: data,
+	}
+	if err := ctrl.SetControllerReference(mce, cm, r.Scheme); err != nil {
+		return fmt.Errorf("error setting controller reference on image list configmap: %w", err)
+	}
+	log.FromContext(ctx).Info(fmt.Sprintf("creating image list configmap %s/%s", cm.Namespace, cm.Name))
+	return r.Client.Create(ctx, cm)
+}
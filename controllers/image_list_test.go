@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_ensureImageList(t *testing.T) {
+	os.Setenv("DIRECTORY_OVERRIDE", "../")
+	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	os.Setenv("ACM_HUB_OCP_VERSION", "4.14.0")
+	defer os.Unsetenv("ACM_HUB_OCP_VERSION")
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: DestinationNamespace,
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{
+					{Name: backplanev1.Discovery, Enabled: true},
+					{Name: backplanev1.Hive, Enabled: false},
+					{Name: backplanev1.ClusterManager, Enabled: false},
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mce).Build()
+	r := &MultiClusterEngineReconciler{
+		Client: cl,
+		Scheme: scheme,
+		Images: map[string]string{
+			"discovery_operator":    "quay.io/stolostron/discovery-operator:latest",
+			"openshift_hive":        "quay.io/stolostron/hive:latest",
+			"registration_operator": "quay.io/stolostron/registration-operator:latest",
+			"registration":          "quay.io/stolostron/registration:latest",
+			"work":                  "quay.io/stolostron/work:latest",
+			"placement":             "quay.io/stolostron/placement:latest",
+			"addon_manager":         "quay.io/stolostron/addon-manager:latest",
+		},
+	}
+	ctx := context.TODO()
+
+	if err := r.ensureImageList(ctx, mce); err != nil {
+		t.Fatalf("ensureImageList() error = %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: imageListName, Namespace: DestinationNamespace}, cm); err != nil {
+		t.Fatalf("expected image list configmap: %v", err)
+	}
+	if want := "quay.io/stolostron/discovery-operator:latest\n"; cm.Data[imageListImagesKey] != want {
+		t.Errorf("images = %q, want %q", cm.Data[imageListImagesKey], want)
+	}
+	wantComponents := "{\n  \"discovery\": [\n    \"quay.io/stolostron/discovery-operator:latest\"\n  ]\n}"
+	if cm.Data[imageListComponentsKey] != wantComponents {
+		t.Errorf("components = %s, want %s", cm.Data[imageListComponentsKey], wantComponents)
+	}
+
+	// Enabling a component adds its images
+	mce.Spec.Overrides.Components[1].Enabled = true
+	if err := r.ensureImageList(ctx, mce); err != nil {
+		t.Fatalf("ensureImageList() error = %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: imageListName, Namespace: DestinationNamespace}, cm); err != nil {
+		t.Fatal(err)
+	}
+	if want := "quay.io/stolostron/discovery-operator:latest\nquay.io/stolostron/hive:latest\n"; cm.Data[imageListImagesKey] != want {
+		t.Errorf("images = %q, want %q", cm.Data[imageListImagesKey], want)
+	}
+
+	// Images of the ClusterManager resource are listed with the cluster-manager chart images
+	mce.Spec.Overrides.Components[2].Enabled = true
+	if err := r.ensureImageList(ctx, mce); err != nil {
+		t.Fatalf("ensureImageList() error = %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: imageListName, Namespace: DestinationNamespace}, cm); err != nil {
+		t.Fatal(err)
+	}
+	for _, image := range []string{"quay.io/stolostron/placement:latest", "quay.io/stolostron/addon-manager:latest", "quay.io/stolostron/registration:latest", "quay.io/stolostron/work:latest"} {
+		if !strings.Contains(cm.Data[imageListImagesKey], image+"\n") {
+			t.Errorf("expected image %s to be listed, got %q", image, cm.Data[imageListImagesKey])
+		}
+	}
+}
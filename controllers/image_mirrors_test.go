@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_resolveImageMirrors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	configv1.AddToScheme(scheme)
+	operatorv1alpha1.AddToScheme(scheme)
+
+	idms := &configv1.ImageDigestMirrorSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "idms"},
+		Spec: configv1.ImageDigestMirrorSetSpec{
+			ImageDigestMirrors: []configv1.ImageDigestMirrors{
+				{Source: "quay.io/stolostron", Mirrors: []configv1.ImageMirror{"mirror.example.com/stolostron"}},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(idms).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme, StatusManager: &status.StatusTracker{Client: cl}}
+	ctx := context.TODO()
+
+	imgs := map[string]string{
+		"console": "quay.io/stolostron/console@sha256:abc",
+		"hive":    "quay.io/openshift-hive/hive@sha256:abc",
+	}
+
+	mce := &backplanev1.MultiClusterEngine{ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName}}
+	got, err := r.resolveImageMirrors(ctx, mce, imgs)
+	if err != nil {
+		t.Fatalf("resolveImageMirrors() error = %v", err)
+	}
+	if got["console"] != imgs["console"] {
+		t.Errorf("images should not be resolved without the annotation, got %s", got["console"])
+	}
+	if len(r.StatusManager.Conditions) != 0 {
+		t.Errorf("expected no conditions without the annotation, got %v", r.StatusManager.Conditions)
+	}
+
+	mce.SetAnnotations(map[string]string{utils.AnnotationResolveImageMirrors: "true"})
+	got, err = r.resolveImageMirrors(ctx, mce, imgs)
+	if err != nil {
+		t.Fatalf("resolveImageMirrors() error = %v", err)
+	}
+	if want := "mirror.example.com/stolostron/console@sha256:abc"; got["console"] != want {
+		t.Errorf("resolveImageMirrors() console = %s, want %s", got["console"], want)
+	}
+	if len(r.StatusManager.Conditions) != 1 {
+		t.Fatalf("expected an ImagesMirrored condition, got %v", r.StatusManager.Conditions)
+	}
+	cond := r.StatusManager.Conditions[0]
+	if cond.Type != backplanev1.MultiClusterEngineImagesMirrored || cond.Status != metav1.ConditionFalse ||
+		cond.Reason != status.ImagesNotMirroredReason || cond.Message != "No mirror found for images: hive" {
+		t.Errorf("unexpected condition %v", cond)
+	}
+
+	mce.SetAnnotations(nil)
+	if _, err := r.resolveImageMirrors(ctx, mce, imgs); err != nil {
+		t.Fatalf("resolveImageMirrors() error = %v", err)
+	}
+	if len(r.StatusManager.Conditions) != 0 {
+		t.Errorf("expected the ImagesMirrored condition to be removed, got %v", r.StatusManager.Conditions)
+	}
+}
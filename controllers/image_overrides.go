@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// setImageOverridesCondition reports the issues found in the image override configmap, or removes
+// the condition when no configmap is referenced
+func (r *MultiClusterEngineReconciler) setImageOverridesCondition(mce *backplanev1.MultiClusterEngine, issues images.OverrideIssues) {
+	if utils.GetImageOverridesConfigmap(mce) == "" {
+		r.StatusManager.RemoveCondition(backplanev1.MultiClusterEngineImageOverridesValid)
+		return
+	}
+	if issues.Empty() {
+		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineImageOverridesValid, metav1.ConditionTrue, status.ImageOverridesAppliedReason, ""))
+		return
+	}
+
+	messages := []string{}
+	if len(issues.Invalid) > 0 {
+		messages = append(messages, fmt.Sprintf("Skipped invalid entries: %s", strings.Join(issues.Invalid, "; ")))
+	}
+	if len(issues.Unknown) > 0 {
+		messages = append(messages, fmt.Sprintf("Unknown image keys: %s", strings.Join(issues.Unknown, ", ")))
+	}
+	r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineImageOverridesValid, metav1.ConditionFalse, status.ImageOverridesInvalidReason, strings.Join(messages, ". ")))
+}
+
+// imageOverridesToMultiClusterEngines maps an image override configmap to the MultiClusterEngines referencing it
+func (r *MultiClusterEngineReconciler) imageOverridesToMultiClusterEngines(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != utils.OperatorNamespace() {
+		return nil
+	}
+	return r.multiClusterEnginesMatching(ctx, func(mce backplanev1.MultiClusterEngine) bool {
+		return utils.GetImageOverridesConfigmap(&mce) == obj.GetName()
+	})
+}
+
+// configMapToMultiClusterEngines maps a configmap to the MultiClusterEngines that read it
+func (r *MultiClusterEngineReconciler) configMapToMultiClusterEngines(ctx context.Context, obj client.Object) []reconcile.Request {
+	return append(r.trustBundleToMultiClusterEngines(ctx, obj), r.imageOverridesToMultiClusterEngines(ctx, obj)...)
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_imageOverrides(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "backplane-operator")
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        BackplaneConfigName,
+			Annotations: map[string]string{utils.AnnotationImageOverridesCM: "overrides"},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mce).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme, StatusManager: &status.StatusTracker{Client: cl}}
+
+	overrides := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "backplane-operator"}}
+	if got := r.configMapToMultiClusterEngines(context.TODO(), overrides); len(got) != 1 || got[0].Name != BackplaneConfigName {
+		t.Errorf("configMapToMultiClusterEngines() = %v, want a request for %s", got, BackplaneConfigName)
+	}
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "other"}}
+	if got := r.configMapToMultiClusterEngines(context.TODO(), other); len(got) != 0 {
+		t.Errorf("configMapToMultiClusterEngines() = %v, want no requests", got)
+	}
+
+	r.setImageOverridesCondition(mce, images.OverrideIssues{Invalid: []string{"a.yaml[0]: missing image-key"}, Unknown: []string{"foo"}})
+	if len(r.StatusManager.Conditions) != 1 {
+		t.Fatalf("expected an ImageOverridesValid condition, got %v", r.StatusManager.Conditions)
+	}
+	cond := r.StatusManager.Conditions[0]
+	want := "Skipped invalid entries: a.yaml[0]: missing image-key. Unknown image keys: foo"
+	if cond.Status != metav1.ConditionFalse || cond.Reason != status.ImageOverridesInvalidReason || cond.Message != want {
+		t.Errorf("unexpected condition %v", cond)
+	}
+
+	r.setImageOverridesCondition(mce, images.OverrideIssues{})
+	if cond := r.StatusManager.Conditions[0]; cond.Status != metav1.ConditionTrue || cond.Reason != status.ImageOverridesAppliedReason {
+		t.Errorf("unexpected condition %v", cond)
+	}
+
+	mce.SetAnnotations(nil)
+	r.setImageOverridesCondition(mce, images.OverrideIssues{})
+	if len(r.StatusManager.Conditions) != 0 {
+		t.Errorf("expected the ImageOverridesValid condition to be removed, got %v", r.StatusManager.Conditions)
+	}
+}
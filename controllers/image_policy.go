@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// componentImagesValid checks that every image the component deploys, from its chart or in the custom
+// resources built for it, is set and, when digests are required, pinned by digest. Otherwise the
+// component is reported as failed and should not be rendered.
+func (r *MultiClusterEngineReconciler) componentImagesValid(ctx context.Context, mce *backplanev1.MultiClusterEngine, component string) bool {
+	summary, err := r.summaries.get(mce, component)
+	if err == nil {
+		err = images.ValidateImages(r.Images, summary.ImageKeys, utils.ShouldRequireImageDigests(mce))
+	}
+	if err == nil {
+		return true
+	}
+
+	log.FromContext(ctx).Info(fmt.Sprintf("Not deploying component %s: %s", component, err.Error()))
+	r.StatusManager.AddComponent(status.StaticStatus{
+		NamespacedName: types.NamespacedName{Name: component, Namespace: mce.Spec.TargetNamespace},
+		Kind:           "Component",
+		Condition: backplanev1.ComponentCondition{
+			Type:      "ImagesInvalid",
+			Name:      component,
+			Status:    metav1.ConditionFalse,
+			Reason:    status.InvalidImagesReason,
+			Kind:      "Component",
+			Available: false,
+			Message:   err.Error(),
+		},
+	})
+	return false
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_componentImagesValid(t *testing.T) {
+	os.Setenv("DIRECTORY_OVERRIDE", "../")
+	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	os.Setenv("ACM_HUB_OCP_VERSION", "4.14.0")
+	defer os.Unsetenv("ACM_HUB_OCP_VERSION")
+
+	scheme := runtime.NewScheme()
+	backplanev1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme, StatusManager: &status.StatusTracker{Client: cl}}
+	ctx := context.TODO()
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: DestinationNamespace},
+	}
+
+	r.Images = map[string]string{}
+	if r.componentImagesValid(ctx, mce, backplanev1.Discovery) {
+		t.Error("expected discovery to be invalid without its image")
+	}
+	component := getComponent(r.StatusManager.ReportStatus(*mce).Components, backplanev1.Discovery)
+	if component.Available || component.Reason != status.InvalidImagesReason || component.Message != "missing images: discovery_operator" {
+		t.Errorf("unexpected component status %v", component)
+	}
+
+	r.StatusManager.Reset("")
+	r.Images = map[string]string{"discovery_operator": "quay.io/stolostron/discovery-operator:latest"}
+	if !r.componentImagesValid(ctx, mce, backplanev1.Discovery) {
+		t.Error("expected discovery to be valid")
+	}
+
+	mce.SetAnnotations(map[string]string{utils.AnnotationRequireImageDigests: "true"})
+	if r.componentImagesValid(ctx, mce, backplanev1.Discovery) {
+		t.Error("expected discovery to be invalid when digests are required")
+	}
+
+	r.StatusManager.Reset("")
+	r.Images["discovery_operator"] = fmt.Sprintf("quay.io/stolostron/discovery-operator@sha256:%064d", 0)
+	if !r.componentImagesValid(ctx, mce, backplanev1.Discovery) {
+		t.Error("expected discovery to be valid when pinned by digest")
+	}
+	if !r.componentImagesValid(ctx, mce, backplanev1.LocalCluster) {
+		t.Error("components without a chart should always be valid")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/metrics"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// unknownComponent labels the errors of resources applied outside of a component
+const unknownComponent = "none"
+
+type componentKey struct{}
+
+// withComponent returns a context recording the component being reconciled
+func withComponent(ctx context.Context, component string) context.Context {
+	return context.WithValue(ctx, componentKey{}, component)
+}
+
+// componentFromContext returns the component being reconciled
+func componentFromContext(ctx context.Context) string {
+	if component, ok := ctx.Value(componentKey{}).(string); ok {
+		return component
+	}
+	return unknownComponent
+}
+
+// reconcileComponent runs the reconcile function of a component, recording its duration and labeling
+// errors applying and deleting its resources with the component
+func (r *MultiClusterEngineReconciler) reconcileComponent(ctx context.Context, component string, mce *backplanev1.MultiClusterEngine,
+	reconcile func(context.Context, *backplanev1.MultiClusterEngine) (ctrl.Result, error)) (ctrl.Result, error) {
+	start := time.Now()
+	defer func() {
+		metrics.ComponentReconcileDuration.WithLabelValues(component).Observe(time.Since(start).Seconds())
+	}()
+	return reconcile(withComponent(ctx, component), mce)
+}
@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/metrics"
+	"github.com/stolostron/backplane-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_reconcileComponent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme, StatusManager: &status.StatusTracker{Client: cl}}
+
+	mce := &backplanev1.MultiClusterEngine{ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName, UID: "uid"}}
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("config")
+	cm.SetNamespace(DestinationNamespace)
+
+	if got := componentFromContext(context.TODO()); got != unknownComponent {
+		t.Errorf("componentFromContext() = %s, want %s", got, unknownComponent)
+	}
+
+	before := testutil.ToFloat64(metrics.ApplyErrors.WithLabelValues(backplanev1.Hive, "ConfigMap"))
+	// The fake client does not support server-side apply, so applying fails
+	_, err := r.reconcileComponent(context.TODO(), backplanev1.Hive, mce, func(ctx context.Context, mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+		if got := componentFromContext(ctx); got != backplanev1.Hive {
+			t.Errorf("componentFromContext() = %s, want %s", got, backplanev1.Hive)
+		}
+		return r.applyTemplate(ctx, mce, cm)
+	})
+	if err == nil {
+		t.Fatal("expected applyTemplate to fail")
+	}
+	if got := testutil.ToFloat64(metrics.ApplyErrors.WithLabelValues(backplanev1.Hive, "ConfigMap")); got != before+1 {
+		t.Errorf("ApplyErrors = %v, want %v", got, before+1)
+	}
+	if got := testutil.CollectAndCount(metrics.ComponentReconcileDuration, "mce_component_reconcile_duration_seconds"); got == 0 {
+		t.Error("expected the reconcile duration to be observed")
+	}
+}
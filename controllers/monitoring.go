@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/monitoring"
+	"github.com/stolostron/backplane-operator/pkg/platform"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// monitoringComponent labels the metrics of the monitoring resources
+const monitoringComponent = "monitoring"
+
+// ensureMonitoring deploys the Service, ServiceMonitor, PrometheusRule and Prometheus RBAC for the operator's metrics when
+// enabled in the spec and the monitoring CRDs are installed, and removes them otherwise. On OpenShift the target
+// namespace is labeled for the cluster monitoring stack while they are deployed.
+func (r *MultiClusterEngineReconciler) ensureMonitoring(ctx context.Context, mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
+	openShift := r.platform().Name() == platform.OpenShift
+	serviceCA := openShift && r.platform().ServiceCAAvailable()
+	resources, err := monitoring.Resources(mce, utils.OperatorNamespace(), openShift, serviceCA)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	installed, err := r.monitoringCRDsInstalled()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if monitoring.Enabled(mce) && !installed {
+		log.FromContext(ctx).Info("Monitoring is enabled but the ServiceMonitor and PrometheusRule CRDs are not installed")
+	}
+
+	if openShift {
+		if err := r.labelMonitoringNamespace(ctx, mce, monitoring.Enabled(mce) && installed); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	for _, res := range resources {
+		var result ctrl.Result
+		if monitoring.Enabled(mce) && installed {
+			result, err = r.applyTemplate(ctx, mce, res)
+		} else {
+			result, err = r.deleteTemplate(ctx, mce, res)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// labelMonitoringNamespace adds or removes the cluster monitoring label on the target namespace
+func (r *MultiClusterEngineReconciler) labelMonitoringNamespace(ctx context.Context, mce *backplanev1.MultiClusterEngine, label bool) error {
+	ns := &corev1.Namespace{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: mce.Spec.TargetNamespace}, ns)
+	if apierrors.IsNotFound(err) && !label {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, labeled := ns.Labels[monitoring.ClusterMonitoringLabel]
+	if labeled == label {
+		return nil
+	}
+	patch := client.MergeFrom(ns.DeepCopy())
+	if label {
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		ns.Labels[monitoring.ClusterMonitoringLabel] = "true"
+	} else {
+		delete(ns.Labels, monitoring.ClusterMonitoringLabel)
+	}
+	return r.Client.Patch(ctx, ns, patch)
+}
+
+// monitoringCRDsInstalled returns true if the ServiceMonitor and PrometheusRule kinds are served
+func (r *MultiClusterEngineReconciler) monitoringCRDsInstalled() (bool, error) {
+	for _, gvk := range []schema.GroupVersionKind{monitoring.ServiceMonitorGVK, monitoring.PrometheusRuleGVK} {
+		_, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if apimeta.IsNoMatchError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
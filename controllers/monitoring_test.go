@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/monitoring"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_ensureMonitoring(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "backplane-operator")
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: DestinationNamespace,
+			Monitoring:      &backplanev1.Monitoring{Enabled: true},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: monitoring.MetricsServiceName, Namespace: "backplane-operator"},
+	}
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: DestinationNamespace, Labels: map[string]string{monitoring.ClusterMonitoringLabel: "true"}},
+	}
+	// The fake client does not serve the monitoring kinds, as on a cluster without the monitoring CRDs
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mce, service, namespace).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme}
+
+	installed, err := r.monitoringCRDsInstalled()
+	if err != nil {
+		t.Fatalf("monitoringCRDsInstalled() error = %v", err)
+	}
+	if installed {
+		t.Fatal("expected the monitoring CRDs not to be installed")
+	}
+
+	if _, err := r.ensureMonitoring(context.TODO(), mce); err != nil {
+		t.Fatalf("ensureMonitoring() error = %v", err)
+	}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: monitoring.MetricsServiceName, Namespace: "backplane-operator"}, &corev1.Service{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the metrics Service to be removed without the monitoring CRDs, got %v", err)
+	}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: DestinationNamespace}, namespace); err != nil {
+		t.Fatalf("failed to get the target namespace: %v", err)
+	}
+	if _, ok := namespace.Labels[monitoring.ClusterMonitoringLabel]; ok {
+		t.Error("expected the cluster monitoring label to be removed from the target namespace without the monitoring CRDs")
+	}
+
+	if err := r.labelMonitoringNamespace(context.TODO(), mce, true); err != nil {
+		t.Fatalf("labelMonitoringNamespace() error = %v", err)
+	}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: DestinationNamespace}, namespace); err != nil {
+		t.Fatalf("failed to get the target namespace: %v", err)
+	}
+	if namespace.Labels[monitoring.ClusterMonitoringLabel] != "true" {
+		t.Errorf("expected the target namespace to be labeled for cluster monitoring, got %v", namespace.Labels)
+	}
+}
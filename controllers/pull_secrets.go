@@ -0,0 +1,152 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// pullSecretCopyLabel marks image pull secrets copied out of the target namespace by the operator
+const pullSecretCopyLabel = "multiclusterengine.openshift.io/pull-secret-copy"
+
+// setPullSecretNamespaces sets the namespaces the MultiClusterEngine's image pull secrets are copied
+// to from its spec: the namespaces the enabled components render into besides the target namespace,
+// or the cluster-manager namespace in hosted mode. They don't depend on how far the reconcile gets,
+// so copies aren't pruned while a component waits to be applied.
+func (r *MultiClusterEngineReconciler) setPullSecretNamespaces(ctx context.Context, mce *backplanev1.MultiClusterEngine) error {
+	namespaces := map[string]bool{}
+	if backplanev1.IsInHostedMode(mce) {
+		if mce.Enabled(backplanev1.ClusterManager) {
+			namespaces[fmt.Sprintf("%s-cluster-manager", mce.Name)] = true
+		}
+		r.pullSecretNamespaces = namespaces
+		return nil
+	}
+
+	components, err := r.enabledComponents(ctx, mce)
+	if err != nil {
+		return err
+	}
+	for _, component := range components {
+		summary, err := r.summaries.get(mce, component)
+		if err != nil {
+			return err
+		}
+		for _, ns := range summary.Namespaces {
+			if ns != mce.Spec.TargetNamespace {
+				namespaces[ns] = true
+			}
+		}
+	}
+	r.pullSecretNamespaces = namespaces
+	return nil
+}
+
+// ensurePullSecrets copies the MultiClusterEngine's image pull secrets from the target namespace
+// into namespace, if it is one of the pull secret namespaces. Each namespace is synced once per
+// reconcile.
+func (r *MultiClusterEngineReconciler) ensurePullSecrets(ctx context.Context, mce *backplanev1.MultiClusterEngine, namespace string) error {
+	if !r.pullSecretNamespaces[namespace] || r.pullSecretsSynced[namespace] {
+		return nil
+	}
+
+	for _, name := range mce.PullSecrets() {
+		source := &corev1.Secret{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: mce.Spec.TargetNamespace}, source)
+		if err != nil {
+			return fmt.Errorf("error getting image pull secret %s: %w", name, err)
+		}
+
+		desired := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{pullSecretCopyLabel: "true"},
+			},
+			Type: source.Type,
+			Data: source.Data,
+		}
+		utils.AddBackplaneConfigLabels(desired, mce.Name)
+		if err := ctrl.SetControllerReference(mce, desired, r.Scheme); err != nil {
+			return fmt.Errorf("error setting controller reference on image pull secret %s: %w", name, err)
+		}
+
+		existing := &corev1.Secret{}
+		err = r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, existing)
+		if apierrors.IsNotFound(err) {
+			if err := r.Client.Create(ctx, desired); err != nil {
+				return fmt.Errorf("error copying image pull secret %s to namespace %s: %w", name, namespace, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error getting image pull secret %s in namespace %s: %w", name, namespace, err)
+		}
+		if existing.Labels[pullSecretCopyLabel] != "true" {
+			// Leave secrets created by someone else alone
+			continue
+		}
+		if reflect.DeepEqual(existing.Data, desired.Data) && existing.Type == desired.Type {
+			continue
+		}
+		existing.Data = desired.Data
+		if existing.Type != desired.Type {
+			// Secret type is immutable
+			if err := r.Client.Delete(ctx, existing); err != nil {
+				return fmt.Errorf("error replacing image pull secret %s in namespace %s: %w", name, namespace, err)
+			}
+			if err := r.Client.Create(ctx, desired); err != nil {
+				return fmt.Errorf("error copying image pull secret %s to namespace %s: %w", name, namespace, err)
+			}
+			continue
+		}
+		if err := r.Client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("error syncing image pull secret %s in namespace %s: %w", name, namespace, err)
+		}
+	}
+
+	if r.pullSecretsSynced == nil {
+		r.pullSecretsSynced = map[string]bool{}
+	}
+	r.pullSecretsSynced[namespace] = true
+	return nil
+}
+
+// prunePullSecrets deletes copied image pull secrets that are no longer in the spec or are outside
+// the pull secret namespaces
+func (r *MultiClusterEngineReconciler) prunePullSecrets(ctx context.Context, mce *backplanev1.MultiClusterEngine) error {
+	log := log.FromContext(ctx)
+
+	copies := &corev1.SecretList{}
+	err := r.Client.List(ctx, copies, client.MatchingLabels{
+		pullSecretCopyLabel:    "true",
+		"backplaneconfig.name": mce.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing copied image pull secrets: %w", err)
+	}
+
+	for i := range copies.Items {
+		secret := &copies.Items[i]
+		if r.pullSecretNamespaces[secret.Namespace] && utils.Contains(mce.PullSecrets(), secret.Name) {
+			continue
+		}
+		log.Info(fmt.Sprintf("Removing image pull secret %s from namespace %s", secret.Name, secret.Namespace))
+		if err := r.Client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error removing image pull secret %s from namespace %s: %w", secret.Name, secret.Namespace, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_pullSecrets(t *testing.T) {
+	t.Setenv("DIRECTORY_OVERRIDE", "../")
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.14.0")
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace:  DestinationNamespace,
+			ImagePullSecret:  "pull-secret",
+			ImagePullSecrets: []string{"mirror-secret"},
+			Overrides: &backplanev1.Overrides{
+				InfrastructureCustomNamespace: "infra",
+				Components: []backplanev1.ComponentConfig{
+					{Name: backplanev1.AssistedService, Enabled: true},
+				},
+			},
+		},
+	}
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: DestinationNamespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("pull")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "mirror-secret", Namespace: DestinationNamespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("mirror")},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mce, secrets[0], secrets[1]).Build()
+	r := &MultiClusterEngineReconciler{Client: cl, Scheme: scheme}
+	ctx := context.TODO()
+
+	if err := r.setPullSecretNamespaces(ctx, mce); err != nil {
+		t.Fatalf("setPullSecretNamespaces() error = %v", err)
+	}
+	if want := map[string]bool{"infra": true}; !reflect.DeepEqual(r.pullSecretNamespaces, want) {
+		t.Errorf("pullSecretNamespaces = %v, want %v", r.pullSecretNamespaces, want)
+	}
+	for _, ns := range []string{"infra", DestinationNamespace, "other"} {
+		if err := r.ensurePullSecrets(ctx, mce, ns); err != nil {
+			t.Fatalf("ensurePullSecrets() error = %v", err)
+		}
+	}
+
+	for _, name := range []string{"pull-secret", "mirror-secret"} {
+		copied := &corev1.Secret{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: "infra"}, copied); err != nil {
+			t.Fatalf("expected secret %s to be copied: %v", name, err)
+		}
+		if copied.Labels[pullSecretCopyLabel] != "true" || copied.Type != corev1.SecretTypeDockerConfigJson {
+			t.Errorf("copied secret %s has labels %v and type %s", name, copied.Labels, copied.Type)
+		}
+	}
+	for _, ns := range []string{DestinationNamespace, "other"} {
+		if r.pullSecretsSynced[ns] {
+			t.Errorf("ensurePullSecrets() should not copy secrets into namespace %s", ns)
+		}
+	}
+
+	// Copies are kept while the component is enabled, even if it wasn't applied in this reconcile
+	r.pullSecretsSynced = map[string]bool{}
+	if err := r.prunePullSecrets(ctx, mce); err != nil {
+		t.Fatalf("prunePullSecrets() error = %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "mirror-secret", Namespace: "infra"}, &corev1.Secret{}); err != nil {
+		t.Errorf("expected mirror-secret to be kept, got %v", err)
+	}
+
+	// The secret removed from the spec is cleaned up
+	mce.Spec.ImagePullSecrets = nil
+	if err := r.prunePullSecrets(ctx, mce); err != nil {
+		t.Fatalf("prunePullSecrets() error = %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "mirror-secret", Namespace: "infra"}, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected mirror-secret to be removed, got %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "pull-secret", Namespace: "infra"}, &corev1.Secret{}); err != nil {
+		t.Errorf("expected pull-secret to be kept, got %v", err)
+	}
+
+	// Copies in namespaces no enabled component deploys into are cleaned up
+	mce.Spec.Overrides.Components[0].Enabled = false
+	if err := r.setPullSecretNamespaces(ctx, mce); err != nil {
+		t.Fatalf("setPullSecretNamespaces() error = %v", err)
+	}
+	if err := r.prunePullSecrets(ctx, mce); err != nil {
+		t.Fatalf("prunePullSecrets() error = %v", err)
+	}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "pull-secret", Namespace: "infra"}, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected pull-secret to be removed, got %v", err)
+	}
+	for _, s := range secrets {
+		if err := cl.Get(ctx, types.NamespacedName{Name: s.Name, Namespace: DestinationNamespace}, &corev1.Secret{}); err != nil {
+			t.Errorf("source secret %s should not be removed: %v", s.Name, err)
+		}
+	}
+}
+
+func Test_setPullSecretNamespacesHosted(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: BackplaneConfigName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: DestinationNamespace,
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{
+					{Name: backplanev1.ClusterManager, Enabled: true},
+				},
+			},
+		},
+	}
+	mce.SetAnnotations(map[string]string{"deploymentmode": string(backplanev1.ModeHosted)})
+	r := &MultiClusterEngineReconciler{}
+
+	if err := r.setPullSecretNamespaces(context.TODO(), mce); err != nil {
+		t.Fatalf("setPullSecretNamespaces() error = %v", err)
+	}
+	if want := map[string]bool{BackplaneConfigName + "-cluster-manager": true}; !reflect.DeepEqual(r.pullSecretNamespaces, want) {
+		t.Errorf("pullSecretNamespaces = %v, want %v", r.pullSecretNamespaces, want)
+	}
+}
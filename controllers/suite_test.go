@@ -28,6 +28,7 @@ import (
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 	hiveconfig "github.com/openshift/hive/apis/hive/v1"
 
 	operatorsapiv2 "github.com/operator-framework/api/pkg/operators/v2"
@@ -42,6 +43,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "github.com/stolostron/backplane-operator/api/v1"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -133,6 +135,9 @@ var _ = BeforeSuite(func() {
 	err = operatorv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = operatorv1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = os.Setenv("POD_NAMESPACE", "default")
 	Expect(err).NotTo(HaveOccurred())
 
@@ -162,11 +167,14 @@ var _ = BeforeSuite(func() {
 
 	upgradeableCondition, _ := utils.NewOperatorCondition(k8sClient, operatorsapiv2.Upgradeable)
 	Expect(err).ToNot(HaveOccurred())
+	crds, errs := renderer.RenderCRDs(renderer.CRDsDir)
+	Expect(errs).To(BeEmpty())
 	reconciler = MultiClusterEngineReconciler{
 		Client:          k8sManager.GetClient(),
 		Scheme:          k8sManager.GetScheme(),
 		StatusManager:   &status.StatusTracker{Client: k8sManager.GetClient()},
 		UpgradeableCond: upgradeableCondition,
+		CRDs:            crds,
 	}
 	err = (reconciler).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
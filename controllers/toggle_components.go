@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"os"
 
-	semver "github.com/Masterminds/semver"
-	configv1 "github.com/openshift/api/config/v1"
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/pkg/foundation"
 	"github.com/stolostron/backplane-operator/pkg/hive"
@@ -45,6 +43,11 @@ func (r *MultiClusterEngineReconciler) ensureConsoleMCE(ctx context.Context, bac
 	r.StatusManager.AddComponent(toggle.EnabledStatus(namespacedName))
 
 	log := log.FromContext(ctx)
+
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.ConsoleMCE) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.ConsoleMCEChartsDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -125,6 +128,10 @@ func (r *MultiClusterEngineReconciler) ensureManagedServiceAccount(ctx context.C
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.ManagedServiceAccount) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	// Render CRD templates
 	crdPath := toggle.ManagedServiceAccountCRDPath
 	crds, errs := renderer.RenderCRDs(crdPath)
@@ -303,6 +310,10 @@ func (r *MultiClusterEngineReconciler) ensureDiscovery(ctx context.Context, back
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.Discovery) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.DiscoveryChartDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -356,6 +367,10 @@ func (r *MultiClusterEngineReconciler) ensureHive(ctx context.Context, backplane
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.Hive) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.HiveChartDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -436,6 +451,10 @@ func (r *MultiClusterEngineReconciler) ensureAssistedService(ctx context.Context
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.AssistedService) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChartWithNamespace(toggle.AssistedServiceChartDir, backplaneConfig, r.Images, targetNamespace)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -500,6 +519,10 @@ func (r *MultiClusterEngineReconciler) ensureServerFoundation(ctx context.Contex
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.ServerFoundation) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.ServerFoundationChartDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -571,6 +594,10 @@ func (r *MultiClusterEngineReconciler) ensureClusterLifecycle(ctx context.Contex
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.ClusterLifecycle) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.ClusterLifecycleChartDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -636,6 +663,10 @@ func (r *MultiClusterEngineReconciler) ensureClusterManager(ctx context.Context,
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.ClusterManager) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.ClusterManagerChartDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -733,6 +764,10 @@ func (r *MultiClusterEngineReconciler) ensureHyperShift(ctx context.Context, bac
 
 	log := log.FromContext(ctx)
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.HyperShift) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.HyperShiftChartDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -961,6 +996,10 @@ func (r *MultiClusterEngineReconciler) ensureClusterProxyAddon(ctx context.Conte
 	r.StatusManager.RemoveComponent(toggle.DisabledStatus(namespacedName, []*unstructured.Unstructured{}))
 	r.StatusManager.AddComponent(status.NewPresentStatus(types.NamespacedName{Name: "cluster-proxy"}, clusterManagementAddOnGVK))
 
+	if !r.componentImagesValid(ctx, backplaneConfig, backplanev1.ClusterProxyAddon) {
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+
 	templates, errs := renderer.RenderChart(toggle.ClusterProxyAddonDir, backplaneConfig, r.Images)
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -1017,41 +1056,9 @@ func (r *MultiClusterEngineReconciler) ensureNoClusterProxyAddon(ctx context.Con
 	return ctrl.Result{}, nil
 }
 
-// Checks if OCP Console is enabled and return true if so. If <OCP v4.12, always return true
-// Otherwise check in the EnabledCapabilities spec for OCP console
+// CheckConsole returns true if the cluster has a console that can load the MCE console plugin
 func (r *MultiClusterEngineReconciler) CheckConsole(ctx context.Context) (bool, error) {
-	versionStatus := &configv1.ClusterVersion{}
-	err := r.Client.Get(ctx, types.NamespacedName{Name: "version"}, versionStatus)
-	if err != nil {
-		return false, err
-	}
-	ocpVersion, err := r.getClusterVersion(ctx)
-	if err != nil {
-		return false, err
-	}
-	if hubOCPVersion, ok := os.LookupEnv("ACM_HUB_OCP_VERSION"); ok {
-		ocpVersion = hubOCPVersion
-	}
-	semverVersion, err := semver.NewVersion(ocpVersion)
-	if err != nil {
-		return false, fmt.Errorf("failed to convert ocp version to semver compatible value: %w", err)
-	}
-	// -0 allows for prerelease builds to pass the validation.
-	// If -0 is removed, developer/rc builds will not pass this check
-	//OCP Console can only be disabled in OCP 4.12+
-	constraint, err := semver.NewConstraint(">= 4.12.0-0")
-	if err != nil {
-		return false, fmt.Errorf("failed to set ocp version constraint: %w", err)
-	}
-	if !constraint.Check(semverVersion) {
-		return true, nil
-	}
-	for _, v := range versionStatus.Status.Capabilities.EnabledCapabilities {
-		if v == "Console" {
-			return true, nil
-		}
-	}
-	return false, nil
+	return r.platform().ConsoleAvailable(ctx, r.Client)
 }
 
 func (r *MultiClusterEngineReconciler) ensureLocalCluster(ctx context.Context, mce *backplanev1.MultiClusterEngine) (ctrl.Result, error) {
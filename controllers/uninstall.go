@@ -5,15 +5,28 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/crds"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
 
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var (
@@ -132,3 +145,115 @@ func (r *MultiClusterEngineReconciler) uninstall(backplaneConfig *backplanev1.Mu
 	}
 	return false, nil
 }
+
+// operatorCRDs are watched by the MultiClusterEngine controller, which can't start without them, so they
+// are retained even though they belong to a component
+var operatorCRDs = map[string]bool{
+	"clustermanagers.operator.open-cluster-management.io": true,
+	"hiveconfigs.hive.openshift.io":                       true,
+}
+
+// removableCRDs returns the names of the CRDs under crdDir that belong to a component and are removed
+// on uninstall when the uninstall policy deletes CRDs
+func removableCRDs(crdDir string) (map[string]bool, error) {
+	components, errs := renderer.RenderComponentCRDs(crdDir)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("error rendering CRDs: %v", errs)
+	}
+	removable := map[string]bool{}
+	for name := range components {
+		if !operatorCRDs[name] {
+			removable[name] = true
+		}
+	}
+	return removable, nil
+}
+
+// removeCRDs deletes the CRDs of components once no custom resources of their type remain. It returns
+// an error until every CRD is gone so that uninstallation does not complete early.
+func (r *MultiClusterEngineReconciler) removeCRDs(ctx context.Context, backplaneConfig *backplanev1.MultiClusterEngine) error {
+	log := log.FromContext(ctx)
+
+	removable, err := removableCRDs(renderer.CRDsDir)
+	if err != nil {
+		return err
+	}
+
+	inUse := []string{}
+	terminating := []string{}
+	for name := range removable {
+		crd := &apixv1.CustomResourceDefinition{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: name}, crd)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if utils.AnnotationPresent(utils.AnnotationMCEIgnore, crd) {
+			continue
+		}
+		if crd.GetDeletionTimestamp() != nil {
+			terminating = append(terminating, crd.GetName())
+			continue
+		}
+
+		remaining, err := r.customResourcesExist(ctx, crd)
+		if err != nil {
+			return err
+		}
+		if remaining {
+			inUse = append(inUse, crd.GetName())
+			continue
+		}
+
+		log.Info(fmt.Sprintf("finalizing CRD %s", crd.GetName()))
+		if err := r.Client.Delete(ctx, crd); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		terminating = append(terminating, crd.GetName())
+	}
+
+	if len(inUse) == 0 && len(terminating) == 0 {
+		return nil
+	}
+	sort.Strings(inUse)
+	sort.Strings(terminating)
+
+	message := ""
+	if len(inUse) > 0 {
+		message = fmt.Sprintf("Waiting for custom resources to be removed before deleting CRDs: %s.", strings.Join(inUse, ", "))
+	}
+	if len(terminating) > 0 {
+		message = strings.TrimSpace(fmt.Sprintf("%s Waiting for CRDs to terminate: %s.", message, strings.Join(terminating, ", ")))
+	}
+	// If wait time exceeds expected then uninstall may not be able to progress
+	if time.Since(backplaneConfig.DeletionTimestamp.Time) < 10*time.Minute {
+		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionTrue, status.WaitingForResourceReason, message))
+	} else {
+		r.StatusManager.AddCondition(status.NewCondition(backplanev1.MultiClusterEngineProgressing, metav1.ConditionFalse, status.WaitingForResourceReason, message))
+	}
+	return fmt.Errorf("waiting for %d CRDs to be removed before proceeding with uninstallation", len(inUse)+len(terminating))
+}
+
+// customResourcesExist returns true if any custom resource of the CRD's type exists in the cluster
+func (r *MultiClusterEngineReconciler) customResourcesExist(ctx context.Context, crd *apixv1.CustomResourceDefinition) (bool, error) {
+	version := crds.StorageVersion(crd)
+	if version == "" {
+		return false, nil
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: version,
+		Kind:    crd.Spec.Names.ListKind,
+	})
+	err := r.Client.List(ctx, list, client.Limit(1))
+	if apimeta.IsNoMatchError(err) || errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error listing %s: %w", crd.Spec.Names.Plural, err)
+	}
+	return len(list.Items) > 0, nil
+}
@@ -0,0 +1,280 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/certs"
+
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	webhookServiceName   = "multicluster-engine-operator-webhook-service"
+	webhookCertSecret    = "multicluster-engine-operator-webhook"
+	webhookCASecret      = "multicluster-engine-operator-webhook-ca"
+	webhookCABundleKey   = "ca-bundle.crt"
+	maxCertRecheckPeriod = 12 * time.Hour
+)
+
+// WebhookCertReconciler issues the webhook serving certificate from a CA managed by the operator,
+// injects the CA into the ValidatingWebhookConfiguration and rotates both before they expire. It is
+// used in place of the OpenShift service-ca operator when that is not available.
+type WebhookCertReconciler struct {
+	client.Client
+	// Namespace the operator and its webhook service run in
+	Namespace string
+	// CertDir is the directory the webhook server reads its serving certificate from
+	CertDir string
+}
+
+// Reconcile ensures the webhook certificates are valid and requeues ahead of the next rotation
+func (r *WebhookCertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	next, err := r.EnsureCertificates(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: next}, nil
+}
+
+// EnsureCertificates creates or rotates the CA and serving certificate, writes the serving certificate
+// to CertDir and injects the CA bundle into the webhook configuration. It returns how long until
+// the certificates should next be checked.
+func (r *WebhookCertReconciler) EnsureCertificates(ctx context.Context) (time.Duration, error) {
+	now := time.Now()
+
+	ca, bundle, err := r.ensureCA(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	serving, err := r.ensureServingCert(ctx, ca, bundle, now)
+	if err != nil {
+		return 0, err
+	}
+	if err := certs.WriteFiles(r.CertDir, serving); err != nil {
+		return 0, err
+	}
+	if err := r.injectCABundle(ctx, bundle); err != nil {
+		return 0, err
+	}
+
+	next := maxCertRecheckPeriod
+	for _, c := range [][]byte{ca.Cert, serving.Cert} {
+		rotation, err := certs.RotationTime(c)
+		if err != nil {
+			return 0, err
+		}
+		if until := rotation.Sub(now); until < next {
+			next = until
+		}
+	}
+	if next < time.Minute {
+		next = time.Minute
+	}
+	return next, nil
+}
+
+// ensureCA returns the operator's CA and the bundle of CA certificates trusted by the webhook. The CA
+// is regenerated when it is invalid or due for rotation, and the previous CA is kept in the bundle
+// until it expires.
+func (r *WebhookCertReconciler) ensureCA(ctx context.Context, now time.Time) (certs.KeyPair, []byte, error) {
+	log := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: webhookCASecret, Namespace: r.Namespace}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return certs.KeyPair{}, nil, fmt.Errorf("error getting secret %s: %w", webhookCASecret, err)
+	}
+	exists := err == nil
+
+	if exists {
+		if ca, bundle, ok := storedCA(secret, now); ok {
+			return ca, bundle, nil
+		}
+	}
+
+	log.Info("Generating webhook CA certificate")
+	previous := secret.Data[webhookCABundleKey]
+	ca, err := certs.NewCA(webhookServiceName, now, certs.CAValidity)
+	if err != nil {
+		return certs.KeyPair{}, nil, err
+	}
+	bundle := certs.Bundle(now, ca.Cert, previous)
+
+	secret.SetName(webhookCASecret)
+	secret.SetNamespace(r.Namespace)
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       ca.Cert,
+		corev1.TLSPrivateKeyKey: ca.Key,
+		webhookCABundleKey:      bundle,
+	}
+	if exists {
+		err = r.Client.Update(ctx, secret)
+	} else {
+		err = r.Client.Create(ctx, secret)
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		// Every replica runs this controller, and another one saved a CA first. Use the stored CA so that
+		// all replicas serve certificates from the same CA.
+		return r.readCA(ctx, now)
+	}
+	if err != nil {
+		return certs.KeyPair{}, nil, fmt.Errorf("error saving secret %s: %w", webhookCASecret, err)
+	}
+	return ca, bundle, nil
+}
+
+// readCA returns the CA stored by another replica, or an error if it is not valid
+func (r *WebhookCertReconciler) readCA(ctx context.Context, now time.Time) (certs.KeyPair, []byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: webhookCASecret, Namespace: r.Namespace}, secret); err != nil {
+		return certs.KeyPair{}, nil, fmt.Errorf("error getting secret %s: %w", webhookCASecret, err)
+	}
+	ca, bundle, ok := storedCA(secret, now)
+	if !ok {
+		return certs.KeyPair{}, nil, fmt.Errorf("secret %s does not hold a valid CA", webhookCASecret)
+	}
+	return ca, bundle, nil
+}
+
+// storedCA returns the CA and bundle held by the secret, and whether the CA can still be used
+func storedCA(secret *corev1.Secret, now time.Time) (certs.KeyPair, []byte, bool) {
+	ca := certs.KeyPair{Cert: secret.Data[corev1.TLSCertKey], Key: secret.Data[corev1.TLSPrivateKeyKey]}
+	rotation, err := certs.RotationTime(ca.Cert)
+	if err != nil || !now.Before(rotation) {
+		return certs.KeyPair{}, nil, false
+	}
+	return ca, certs.Bundle(now, ca.Cert, secret.Data[webhookCABundleKey]), true
+}
+
+// ensureServingCert returns the webhook serving certificate, issuing a new one from the CA when the
+// current certificate is invalid or due for rotation
+func (r *WebhookCertReconciler) ensureServingCert(ctx context.Context, ca certs.KeyPair, bundle []byte, now time.Time) (certs.KeyPair, error) {
+	log := log.FromContext(ctx)
+	dnsNames := []string{
+		webhookServiceName,
+		fmt.Sprintf("%s.%s", webhookServiceName, r.Namespace),
+		fmt.Sprintf("%s.%s.svc", webhookServiceName, r.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", webhookServiceName, r.Namespace),
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: webhookCertSecret, Namespace: r.Namespace}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return certs.KeyPair{}, fmt.Errorf("error getting secret %s: %w", webhookCertSecret, err)
+	}
+	exists := err == nil
+
+	serving := certs.KeyPair{Cert: secret.Data[corev1.TLSCertKey], Key: secret.Data[corev1.TLSPrivateKeyKey]}
+	if exists && certs.ValidServingCert(serving, ca.Cert, dnsNames, now) == nil {
+		rotation, err := certs.RotationTime(serving.Cert)
+		if err == nil && now.Before(rotation) && bytes.Equal(secret.Data["ca.crt"], bundle) {
+			return serving, nil
+		}
+	}
+
+	log.Info("Generating webhook serving certificate")
+	serving, err = certs.NewServingCert(ca, dnsNames, now, certs.ServingValidity)
+	if err != nil {
+		return certs.KeyPair{}, err
+	}
+
+	secret.SetName(webhookCertSecret)
+	secret.SetNamespace(r.Namespace)
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       serving.Cert,
+		corev1.TLSPrivateKeyKey: serving.Key,
+		"ca.crt":                bundle,
+	}
+	if exists {
+		err = r.Client.Update(ctx, secret)
+	} else {
+		err = r.Client.Create(ctx, secret)
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		// Another replica saved a serving certificate first. Use it if it was issued from the same CA.
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: webhookCertSecret, Namespace: r.Namespace}, secret); err != nil {
+			return certs.KeyPair{}, fmt.Errorf("error getting secret %s: %w", webhookCertSecret, err)
+		}
+		serving = certs.KeyPair{Cert: secret.Data[corev1.TLSCertKey], Key: secret.Data[corev1.TLSPrivateKeyKey]}
+		if err := certs.ValidServingCert(serving, ca.Cert, dnsNames, now); err != nil {
+			return certs.KeyPair{}, fmt.Errorf("secret %s saved by another replica is not valid: %w", webhookCertSecret, err)
+		}
+		return serving, nil
+	}
+	if err != nil {
+		return certs.KeyPair{}, fmt.Errorf("error saving secret %s: %w", webhookCertSecret, err)
+	}
+	return serving, nil
+}
+
+// injectCABundle sets the CA bundle on every webhook of the operator's ValidatingWebhookConfiguration
+func (r *WebhookCertReconciler) injectCABundle(ctx context.Context, bundle []byte) error {
+	vwc := &admissionregistration.ValidatingWebhookConfiguration{}
+	name := backplanev1.ValidatingWebhook(r.Namespace).GetName()
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name}, vwc)
+	if apierrors.IsNotFound(err) {
+		// The bundle is injected once the webhook configuration is created
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting validatingwebhookconfiguration %s: %w", name, err)
+	}
+
+	updated := false
+	for i := range vwc.Webhooks {
+		if !bytes.Equal(vwc.Webhooks[i].ClientConfig.CABundle, bundle) {
+			vwc.Webhooks[i].ClientConfig.CABundle = bundle
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := r.Client.Update(ctx, vwc); err != nil {
+		return fmt.Errorf("error injecting CA bundle into validatingwebhookconfiguration %s: %w", name, err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Every replica runs it, since each one
+// serves the webhook from its own copy of the certificate.
+func (r *WebhookCertReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	name := backplanev1.ValidatingWebhook(r.Namespace).GetName()
+	initialSync := make(chan event.GenericEvent, 1)
+	initialSync <- event.GenericEvent{
+		Object: &admissionregistration.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}},
+	}
+
+	needLeaderElection := false
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("webhook-cert").
+		WithOptions(controller.Options{NeedLeaderElection: &needLeaderElection}).
+		For(&admissionregistration.ValidatingWebhookConfiguration{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetName() == name
+			}),
+		)).
+		WatchesRawSource(&source.Channel{Source: initialSync}, &handler.EnqueueRequestForObject{}).
+		Complete(r)
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func Test_WebhookCertReconciler_concurrentReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	admissionregistration.AddToScheme(scheme)
+
+	ctx := context.TODO()
+	ns := "test-ns"
+	raced := map[string]bool{}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		// Another replica saves each secret just before this one does
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if raced[obj.GetName()] {
+				return c.Create(ctx, obj, opts...)
+			}
+			raced[obj.GetName()] = true
+			other := &WebhookCertReconciler{Client: c, Namespace: ns, CertDir: t.TempDir()}
+			if _, err := other.EnsureCertificates(ctx); err != nil {
+				t.Fatalf("EnsureCertificates() of other replica error = %v", err)
+			}
+			return apierrors.NewAlreadyExists(corev1.Resource("secrets"), obj.GetName())
+		},
+	}).Build()
+
+	r := &WebhookCertReconciler{Client: cl, Namespace: ns, CertDir: t.TempDir()}
+	ca, bundle, err := r.ensureCA(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("ensureCA() error = %v", err)
+	}
+	if _, err := r.ensureServingCert(ctx, ca, bundle, time.Now()); err != nil {
+		t.Fatalf("ensureServingCert() error = %v", err)
+	}
+
+	stored := &corev1.Secret{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: webhookCASecret, Namespace: ns}, stored); err != nil {
+		t.Fatalf("failed to get CA secret: %v", err)
+	}
+	if !bytes.Equal(ca.Cert, stored.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected the CA stored by the other replica to be used")
+	}
+}
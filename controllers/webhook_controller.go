@@ -0,0 +1,312 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	mceCRDName = "multiclusterengines.multicluster.openshift.io"
+
+	// WebhookAvailableCondition is the operator condition reporting the health of the MCE webhook
+	WebhookAvailableCondition = "WebhookAvailable"
+	webhookAvailableReason    = "WebhookAvailable"
+	webhookUnavailableReason  = "WebhookUnavailable"
+
+	webhookSelfTestName   = "webhook-self-test"
+	webhookSelfTestPeriod = 5 * time.Minute
+)
+
+// WebhookReconciler keeps the MultiClusterEngine ValidatingWebhookConfiguration and its Service
+// in the desired state, and verifies that the webhook is serving admission requests
+type WebhookReconciler struct {
+	client.Client
+	// Namespace the operator and its webhook service run in
+	Namespace string
+	// HealthCondition reports the result of the webhook self-test
+	HealthCondition utils.Condition
+}
+
+// Reconcile repairs the webhook Service and configuration, then runs the self-test
+func (r *WebhookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	if err := r.ensureService(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.ensureWebhookConfiguration(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err := r.selfTest(ctx)
+	if err != nil {
+		log.Info(fmt.Sprintf("Webhook self-test failed: %s", err.Error()))
+		if condErr := r.HealthCondition.Set(ctx, metav1.ConditionFalse, webhookUnavailableReason, err.Error()); condErr != nil {
+			return ctrl.Result{}, condErr
+		}
+		return ctrl.Result{RequeueAfter: requeuePeriod}, nil
+	}
+	if err := r.HealthCondition.Set(ctx, metav1.ConditionTrue, webhookAvailableReason, ""); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: webhookSelfTestPeriod}, nil
+}
+
+// webhookService returns the desired Service fronting the operator's webhook server
+func (r *WebhookReconciler) webhookService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      webhookServiceName,
+			Namespace: r.Namespace,
+			Annotations: map[string]string{
+				"service.beta.openshift.io/serving-cert-secret-name": webhookCertSecret,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Port:       443,
+					TargetPort: intstr.FromInt(9443),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: map[string]string{"control-plane": "backplane-operator"},
+		},
+	}
+}
+
+// ensureService creates the webhook Service or repairs its ports, selector and annotations
+func (r *WebhookReconciler) ensureService(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	desired := r.webhookService()
+
+	existing := &corev1.Service{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating webhook service")
+		return r.Client.Create(ctx, desired)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting webhook service: %w", err)
+	}
+
+	updated := false
+	if !servicePortsMatch(existing.Spec.Ports, desired.Spec.Ports) {
+		existing.Spec.Ports = desired.Spec.Ports
+		updated = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		existing.Spec.Selector = desired.Spec.Selector
+		updated = true
+	}
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range desired.GetAnnotations() {
+		if annotations[k] != v {
+			annotations[k] = v
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	existing.SetAnnotations(annotations)
+	log.Info("Repairing webhook service")
+	return r.Client.Update(ctx, existing)
+}
+
+// ensureWebhookConfiguration creates the ValidatingWebhookConfiguration or repairs its webhooks. The
+// CA bundle is preserved, since it is injected separately.
+func (r *WebhookReconciler) ensureWebhookConfiguration(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	desired := backplanev1.ValidatingWebhook(r.Namespace)
+
+	// Set the MCE CRD as owner of the webhook so it is removed along with the CRD
+	owner := &apixv1.CustomResourceDefinition{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: mceCRDName}, owner); err != nil {
+		return fmt.Errorf("error getting MCE CRD: %w", err)
+	}
+	desired.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion: "apiextensions.k8s.io/v1",
+			Kind:       "CustomResourceDefinition",
+			Name:       owner.Name,
+			UID:        owner.UID,
+		},
+	})
+
+	existing := &admissionregistration.ValidatingWebhookConfiguration{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: desired.GetName()}, existing)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating validatingwebhookconfiguration")
+		return r.Client.Create(ctx, desired)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting validatingwebhookconfiguration: %w", err)
+	}
+
+	caBundles := map[string][]byte{}
+	for _, w := range existing.Webhooks {
+		caBundles[w.Name] = w.ClientConfig.CABundle
+	}
+	for i := range desired.Webhooks {
+		desired.Webhooks[i].ClientConfig.CABundle = caBundles[desired.Webhooks[i].Name]
+	}
+
+	updated := false
+	if webhooksDrifted(existing.Webhooks, desired.Webhooks) {
+		existing.Webhooks = desired.Webhooks
+		updated = true
+	}
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range desired.GetAnnotations() {
+		if annotations[k] != v {
+			annotations[k] = v
+			updated = true
+		}
+	}
+	if len(existing.GetOwnerReferences()) == 0 {
+		existing.SetOwnerReferences(desired.GetOwnerReferences())
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	existing.SetAnnotations(annotations)
+	log.Info("Repairing validatingwebhookconfiguration")
+	return r.Client.Update(ctx, existing)
+}
+
+// selfTest submits an invalid MultiClusterEngine as a dry run and expects the webhook to reject it.
+// Any other outcome means admission requests are not reaching the webhook.
+func (r *WebhookReconciler) selfTest(ctx context.Context) error {
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: webhookSelfTestName},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			AvailabilityConfig: backplanev1.AvailabilityType(webhookSelfTestName),
+		},
+	}
+	err := r.Client.Create(ctx, mce, client.DryRunAll)
+	if err == nil {
+		return fmt.Errorf("webhook admitted an invalid MultiClusterEngine")
+	}
+	if !strings.Contains(err.Error(), backplanev1.ErrInvalidAvailability.Error()) {
+		return fmt.Errorf("webhook did not respond to an admission request: %w", err)
+	}
+	return nil
+}
+
+// webhooksDrifted returns true if the fields the operator sets on the webhooks differ. Fields
+// defaulted by the API server are ignored.
+func webhooksDrifted(existing, desired []admissionregistration.ValidatingWebhook) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		e, d := existing[i], desired[i]
+		if e.Name != d.Name ||
+			!reflect.DeepEqual(e.AdmissionReviewVersions, d.AdmissionReviewVersions) ||
+			!clientConfigMatch(e.ClientConfig, d.ClientConfig) ||
+			!reflect.DeepEqual(e.FailurePolicy, d.FailurePolicy) ||
+			!reflect.DeepEqual(e.SideEffects, d.SideEffects) ||
+			len(e.Rules) != len(d.Rules) {
+			return true
+		}
+		for j := range d.Rules {
+			er, dr := e.Rules[j], d.Rules[j]
+			if !reflect.DeepEqual(er.Operations, dr.Operations) ||
+				!reflect.DeepEqual(er.APIGroups, dr.APIGroups) ||
+				!reflect.DeepEqual(er.APIVersions, dr.APIVersions) ||
+				!reflect.DeepEqual(er.Resources, dr.Resources) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// clientConfigMatch returns true if both configs route to the same service path with the same CA bundle
+func clientConfigMatch(existing, desired admissionregistration.WebhookClientConfig) bool {
+	if !reflect.DeepEqual(existing.URL, desired.URL) || !reflect.DeepEqual(existing.CABundle, desired.CABundle) {
+		return false
+	}
+	if existing.Service == nil || desired.Service == nil {
+		return existing.Service == desired.Service
+	}
+	return existing.Service.Name == desired.Service.Name &&
+		existing.Service.Namespace == desired.Service.Namespace &&
+		reflect.DeepEqual(existing.Service.Path, desired.Service.Path)
+}
+
+// servicePortsMatch returns true if the ports expose the same port, target port and protocol
+func servicePortsMatch(existing, desired []corev1.ServicePort) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for i := range desired {
+		if existing[i].Port != desired[i].Port ||
+			existing[i].TargetPort != desired[i].TargetPort ||
+			existing[i].Protocol != desired[i].Protocol {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WebhookReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	name := backplanev1.ValidatingWebhook(r.Namespace).GetName()
+	request := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+	initialSync := make(chan event.GenericEvent, 1)
+	initialSync <- event.GenericEvent{
+		Object: &admissionregistration.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("webhook").
+		For(&admissionregistration.ValidatingWebhookConfiguration{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetName() == name
+			}),
+		)).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return request
+			}), builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetName() == webhookServiceName && o.GetNamespace() == r.Namespace
+			}),
+		)).
+		WatchesRawSource(&source.Channel{Source: initialSync}, &handler.EnqueueRequestForObject{}).
+		Complete(r)
+}
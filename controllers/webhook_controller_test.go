@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_WebhookReconciler_repairs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	admissionregistration.AddToScheme(scheme)
+	apixv1.AddToScheme(scheme)
+
+	ctx := context.TODO()
+	ns := "test-ns"
+	crd := &apixv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: mceCRDName, UID: "crd-uid"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+	r := &WebhookReconciler{Client: cl, Namespace: ns}
+
+	t.Run("creates missing resources", func(t *testing.T) {
+		if err := r.ensureService(ctx); err != nil {
+			t.Fatalf("ensureService() error = %v", err)
+		}
+		if err := r.ensureWebhookConfiguration(ctx); err != nil {
+			t.Fatalf("ensureWebhookConfiguration() error = %v", err)
+		}
+
+		svc := &corev1.Service{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: webhookServiceName, Namespace: ns}, svc); err != nil {
+			t.Errorf("webhook service not created: %v", err)
+		}
+		vwc := &admissionregistration.ValidatingWebhookConfiguration{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: mceCRDName}, vwc); err != nil {
+			t.Fatalf("validatingwebhookconfiguration not created: %v", err)
+		}
+		if len(vwc.GetOwnerReferences()) != 1 || vwc.GetOwnerReferences()[0].UID != crd.UID {
+			t.Errorf("validatingwebhookconfiguration should be owned by the MCE CRD")
+		}
+	})
+
+	t.Run("repairs drift and keeps the CA bundle", func(t *testing.T) {
+		vwc := &admissionregistration.ValidatingWebhookConfiguration{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: mceCRDName}, vwc); err != nil {
+			t.Fatalf("failed to get validatingwebhookconfiguration: %v", err)
+		}
+		vwc.Webhooks[0].ClientConfig.CABundle = []byte("ca")
+		vwc.Webhooks[0].Rules[0].Operations = []admissionregistration.OperationType{admissionregistration.Create}
+		if err := cl.Update(ctx, vwc); err != nil {
+			t.Fatalf("failed to update validatingwebhookconfiguration: %v", err)
+		}
+
+		svc := &corev1.Service{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: webhookServiceName, Namespace: ns}, svc); err != nil {
+			t.Fatalf("failed to get webhook service: %v", err)
+		}
+		svc.Spec.Selector = map[string]string{"app": "other"}
+		if err := cl.Update(ctx, svc); err != nil {
+			t.Fatalf("failed to update webhook service: %v", err)
+		}
+
+		if err := r.ensureService(ctx); err != nil {
+			t.Fatalf("ensureService() error = %v", err)
+		}
+		if err := r.ensureWebhookConfiguration(ctx); err != nil {
+			t.Fatalf("ensureWebhookConfiguration() error = %v", err)
+		}
+
+		if err := cl.Get(ctx, types.NamespacedName{Name: mceCRDName}, vwc); err != nil {
+			t.Fatalf("failed to get validatingwebhookconfiguration: %v", err)
+		}
+		if webhooksDrifted(vwc.Webhooks, withCABundle(backplanev1.ValidatingWebhook(ns).Webhooks, []byte("ca"))) {
+			t.Errorf("validatingwebhookconfiguration was not repaired")
+		}
+		if err := cl.Get(ctx, types.NamespacedName{Name: webhookServiceName, Namespace: ns}, svc); err != nil {
+			t.Fatalf("failed to get webhook service: %v", err)
+		}
+		if svc.Spec.Selector["control-plane"] != "backplane-operator" {
+			t.Errorf("webhook service selector was not repaired")
+		}
+	})
+}
+
+func withCABundle(webhooks []admissionregistration.ValidatingWebhook, ca []byte) []admissionregistration.ValidatingWebhook {
+	for i := range webhooks {
+		webhooks[i].ClientConfig.CABundle = ca
+	}
+	return webhooks
+}
+
+func Test_webhooksDrifted(t *testing.T) {
+	desired := backplanev1.ValidatingWebhook("test-ns").Webhooks
+
+	defaulted := backplanev1.ValidatingWebhook("test-ns").Webhooks
+	port := int32(443)
+	timeout := int32(10)
+	defaulted[0].ClientConfig.Service.Port = &port
+	defaulted[0].TimeoutSeconds = &timeout
+	defaulted[0].NamespaceSelector = &metav1.LabelSelector{}
+	if webhooksDrifted(defaulted, desired) {
+		t.Errorf("webhooksDrifted() should ignore fields defaulted by the API server")
+	}
+
+	moved := backplanev1.ValidatingWebhook("other-ns").Webhooks
+	if !webhooksDrifted(moved, desired) {
+		t.Errorf("webhooksDrifted() should detect a changed service reference")
+	}
+}
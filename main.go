@@ -25,11 +25,16 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	operatorsapiv2 "github.com/operator-framework/api/pkg/operators/v2"
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
 	"github.com/stolostron/backplane-operator/controllers"
+	"github.com/stolostron/backplane-operator/pkg/cli"
+	"github.com/stolostron/backplane-operator/pkg/gather"
+	"github.com/stolostron/backplane-operator/pkg/monitoring"
+	"github.com/stolostron/backplane-operator/pkg/platform"
 	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
 	"github.com/stolostron/backplane-operator/pkg/status"
 	"github.com/stolostron/backplane-operator/pkg/utils"
@@ -39,30 +44,30 @@ import (
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
 	hiveconfig "github.com/openshift/hive/apis/hive/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
-	"k8s.io/client-go/util/retry"
 
 	"go.uber.org/zap/zapcore"
 	admissionregistration "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -70,21 +75,25 @@ import (
 )
 
 const (
-	crdName    = "multiclusterengines.multicluster.openshift.io"
-	crdsDir    = "pkg/templates/crds"
 	NoCacheEnv = "DISABLE_CLIENT_CACHE"
 )
 
+var selfManagedCertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "self-managed-certs")
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
 )
 
-func init() {
-	if _, exists := os.LookupEnv("OPERATOR_VERSION"); !exists {
-		panic("OPERATOR_VERSION not defined")
-	}
+// subcommands run in place of the operator
+var subcommands = map[string]func(args []string) error{
+	"render":   func(args []string) error { return cli.Render(args, os.Stdout) },
+	"diff":     func(args []string) error { return cli.Diff(args, os.Stdout) },
+	"validate": func(args []string) error { return cli.Validate(args, os.Stdout) },
+	"gather":   func(args []string) error { return cli.Gather(args, os.Stdout) },
+}
 
+func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(backplanev1.AddToScheme(scheme))
@@ -107,19 +116,41 @@ func init() {
 
 	utilruntime.Must(operatorv1.AddToScheme(scheme))
 
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+
 	//+kubebuilder:scaffold:scheme
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			ctrl.SetLogger(zap.New())
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[1], err.Error())
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	if _, exists := os.LookupEnv("OPERATOR_VERSION"); !exists {
+		panic("OPERATOR_VERSION not defined")
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var diagnosticsAddr string
+	var secureMetricsAddr string
 
 	var leaseDuration time.Duration
 	var renewDeadline time.Duration
 	var retryPeriod time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&secureMetricsAddr, "secure-metrics-bind-address", fmt.Sprintf(":%d", monitoring.SecureMetricsPort),
+		"The address the metrics endpoint scraped by Prometheus binds to. It is served over HTTPS and requires a bearer token. Set to 0 to disable it.")
+	flag.StringVar(&diagnosticsAddr, "diagnostics-bind-address", "127.0.0.1:8082", "The loopback address the diagnostics endpoint binds to. Set to 0 to disable it.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -148,6 +179,27 @@ func main() {
 
 	ctrl.Log.WithName("Backplane Operator version").Info(fmt.Sprintf("%#v", version.Get()))
 
+	dc, err := discovery.NewDiscoveryClientForConfig(ctrl.GetConfigOrDie())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	clusterPlatform, err := platform.Detect(dc)
+	if err != nil {
+		setupLog.Error(err, "unable to detect cluster platform")
+		os.Exit(1)
+	}
+	setupLog.Info(fmt.Sprintf("Detected %s platform", clusterPlatform.Name()))
+
+	// Without the OpenShift service-ca operator, the operator issues its own webhook serving certificate
+	webhookOptions := webhook.Options{TLSMinVersion: "1.2"}
+	selfManagedCerts := false
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" && !clusterPlatform.ServiceCAAvailable() {
+		setupLog.Info("service-ca not available. Managing webhook certificates in operator.")
+		selfManagedCerts = true
+		webhookOptions.CertDir = selfManagedCertDir
+	}
+
 	mgrOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -155,7 +207,7 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "797f9276.open-cluster-management.io",
-		WebhookServer:          webhook.NewServer(webhook.Options{TLSMinVersion: "1.2"}),
+		WebhookServer:          webhook.NewServer(webhookOptions),
 		LeaseDuration:          &leaseDuration,
 		RenewDeadline:          &renewDeadline,
 		RetryPeriod:            &retryPeriod,
@@ -170,6 +222,16 @@ func main() {
 		&rbacv1.RoleBinding{},
 		&corev1.ConfigMap{},
 		&corev1.ServiceAccount{},
+		// Pods are only read to explain unavailable deployments
+		&corev1.Pod{},
+		// Endpoints are only read to check the services behind webhooks and console plugins
+		&corev1.Endpoints{},
+	}
+
+	// ConfigMaps and Secrets are only cached as metadata, for the watches of the config MultiClusterEngines read
+	mgrOptions.Cache.ByObject = map[client.Object]cache.ByObject{
+		&corev1.ConfigMap{}: {Transform: controllers.TrimConfigMetadata},
+		&corev1.Secret{}:    {Transform: controllers.TrimConfigMetadata},
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
@@ -209,46 +271,70 @@ func main() {
 		os.Exit(1)
 	}
 
+	crdReconciler := &controllers.CRDReconciler{
+		Client: mgr.GetClient(),
+	}
+	if err = crdReconciler.SetupWithManager(mgr, renderer.CRDsDir); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CustomResourceDefinition")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.MultiClusterEngineReconciler{
 		Client:          mgr.GetClient(),
 		Scheme:          mgr.GetScheme(),
 		StatusManager:   &status.StatusTracker{Client: mgr.GetClient()},
 		UpgradeableCond: upgradeableCondition,
+		Platform:        clusterPlatform,
+		Recorder:        mgr.GetEventRecorderFor("multicluster-engine-operator"),
+		CRDs:            crdReconciler.DesiredCRDs(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MultiClusterEngine")
 		os.Exit(1)
 	}
 
-	// Render CRD templates
-	crdsDir := crdsDir
-	crds, errs := renderer.RenderCRDs(crdsDir)
-	if len(errs) > 0 {
-		for _, err := range errs {
-			setupLog.Info(err.Error())
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		// https://book.kubebuilder.io/cronjob-tutorial/running.html#running-webhooks-locally, https://book.kubebuilder.io/multiversion-tutorial/webhooks.html#and-maingo
+		deploymentNamespace, ok := os.LookupEnv("POD_NAMESPACE")
+		if !ok {
+			setupLog.Info("Failing due to being unable to locate webhook service namespace")
+			os.Exit(1)
 		}
-		os.Exit(1)
-	}
 
-	// udpate CRDs with retry
-	for i := range crds {
-		retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			crd := crds[i]
-			e := ensureCRD(context.TODO(), uncachedClient, crd)
-			return e
-		})
-		if retryErr != nil {
-			setupLog.Error(err, "unable to ensure CRD exists in alloted time. Failing.")
+		webhookCondition, err := utils.NewOperatorCondition(uncachedClient, controllers.WebhookAvailableCondition)
+		if err != nil {
+			setupLog.Error(err, "Cannot create the WebhookAvailable Operator Condition")
 			os.Exit(1)
 		}
-	}
-
-	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		// https://book.kubebuilder.io/cronjob-tutorial/running.html#running-webhooks-locally, https://book.kubebuilder.io/multiversion-tutorial/webhooks.html#and-maingo
-		if err = ensureWebhooks(uncachedClient); err != nil {
-			setupLog.Error(err, "unable to ensure webhook", "webhook", "MultiClusterEngine")
+		if err = (&controllers.WebhookReconciler{
+			Client:          mgr.GetClient(),
+			Namespace:       deploymentNamespace,
+			HealthCondition: webhookCondition,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Webhook")
 			os.Exit(1)
 		}
 
+		if selfManagedCerts {
+			certReconciler := &controllers.WebhookCertReconciler{
+				Client:    mgr.GetClient(),
+				Namespace: deploymentNamespace,
+				CertDir:   selfManagedCertDir,
+			}
+			// Issue the certificate before the webhook server starts, since it needs one to serve
+			if _, err = (&controllers.WebhookCertReconciler{
+				Client:    uncachedClient,
+				Namespace: certReconciler.Namespace,
+				CertDir:   certReconciler.CertDir,
+			}).EnsureCertificates(ctx); err != nil {
+				setupLog.Error(err, "unable to ensure webhook certificates")
+				os.Exit(1)
+			}
+			if err = certReconciler.SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "WebhookCert")
+				os.Exit(1)
+			}
+		}
+
 		if err = (&backplanev1.MultiClusterEngine{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "MultiClusterEngine")
 			os.Exit(1)
@@ -265,6 +351,34 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Serve metrics over HTTPS to the scrapers allowed to read them
+	if secureMetricsAddr != "0" {
+		serviceCA := clusterPlatform.Name() == platform.OpenShift && clusterPlatform.ServiceCAAvailable()
+		metricsServer := monitoring.NewMetricsServer(secureMetricsAddr, os.Getenv("POD_NAMESPACE"), uncachedClient, metrics.Registry, serviceCA)
+		if err := mgr.Add(metricsServer); err != nil {
+			setupLog.Error(err, "unable to set up secure metrics endpoint")
+			os.Exit(1)
+		}
+	}
+
+	// Serve diagnostics on a loopback address, apart from the metrics endpoint which listens on every interface
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+	if diagnosticsAddr != "0" {
+		gatherer := &gather.Gatherer{Client: uncachedClient, Clientset: clientset, OperatorNamespace: os.Getenv("POD_NAMESPACE")}
+		diagnostics, err := gather.NewServer(diagnosticsAddr, gatherer)
+		if err == nil {
+			err = mgr.Add(diagnostics)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up diagnostics endpoint")
+			os.Exit(1)
+		}
+	}
+
 	multiclusterengineList := &backplanev1.MultiClusterEngineList{}
 	err = uncachedClient.List(context.TODO(), multiclusterengineList)
 	if err != nil {
@@ -286,101 +400,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func ensureCRD(ctx context.Context, c client.Client, crd *unstructured.Unstructured) error {
-	existingCRD := &unstructured.Unstructured{}
-	existingCRD.SetGroupVersionKind(crd.GroupVersionKind())
-	err := c.Get(ctx, types.NamespacedName{Name: crd.GetName()}, existingCRD)
-	if err != nil && errors.IsNotFound(err) {
-		// CRD not found. Create and return
-		setupLog.Info(fmt.Sprintf("creating CRD '%s'", crd.GetName()))
-		err = c.Create(ctx, crd)
-		if err != nil {
-			return fmt.Errorf("error creating CRD '%s': %w", crd.GetName(), err)
-		}
-	} else if err != nil {
-		return fmt.Errorf("error getting CRD '%s': %w", crd.GetName(), err)
-	} else if err == nil {
-		// CRD already exists. Update and return
-		if utils.AnnotationPresent(utils.AnnotationMCEIgnore, existingCRD) {
-			setupLog.Info(fmt.Sprintf("CRD '%s' has ignore label. Skipping update.", crd.GetName()))
-			return nil
-		}
-		crd.SetResourceVersion(existingCRD.GetResourceVersion())
-		setupLog.Info(fmt.Sprintf("updating CRD '%s'", crd.GetName()))
-		err = c.Update(ctx, crd)
-		if err != nil {
-			return fmt.Errorf("error updating CRD '%s': %w", crd.GetName(), err)
-		}
-	}
-	return nil
-}
-
-func ensureWebhooks(k8sClient client.Client) error {
-	ctx := context.Background()
-
-	deploymentNamespace, ok := os.LookupEnv("POD_NAMESPACE")
-	if !ok {
-		setupLog.Info("Failing due to being unable to locate webhook service namespace")
-		os.Exit(1)
-	}
-
-	validatingWebhook := backplanev1.ValidatingWebhook(deploymentNamespace)
-
-	maxAttempts := 10
-	for i := 0; i < maxAttempts; i++ {
-		setupLog.Info("Applying ValidatingWebhookConfiguration")
-
-		// Get reference to MCE CRD to set as owner of the webhook
-		// This way if the CRD is deleted the webhook will be removed with it
-		crdKey := types.NamespacedName{Name: crdName}
-		owner := &apixv1.CustomResourceDefinition{}
-		if err := k8sClient.Get(context.TODO(), crdKey, owner); err != nil {
-			setupLog.Error(err, "Failed to get MCE CRD")
-			time.Sleep(5 * time.Second)
-			continue
-		}
-		validatingWebhook.SetOwnerReferences([]metav1.OwnerReference{
-			{
-				APIVersion: "apiextensions.k8s.io/v1",
-				Kind:       "CustomResourceDefinition",
-				Name:       owner.Name,
-				UID:        owner.UID,
-			},
-		})
-
-		existingWebhook := &admissionregistration.ValidatingWebhookConfiguration{}
-		existingWebhook.SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   "admissionregistration.k8s.io",
-			Version: "v1",
-			Kind:    "ValidatingWebhookConfiguration",
-		})
-		err := k8sClient.Get(ctx, types.NamespacedName{Name: validatingWebhook.GetName()}, existingWebhook)
-		if err != nil && errors.IsNotFound(err) {
-			// Webhook not found. Create and return
-			err = k8sClient.Create(ctx, validatingWebhook)
-			if err != nil {
-				setupLog.Error(err, "Error creating validatingwebhookconfiguration")
-				time.Sleep(5 * time.Second)
-				continue
-			}
-			return nil
-		} else if err != nil {
-			setupLog.Error(err, "Error getting validatingwebhookconfiguration")
-			time.Sleep(5 * time.Second)
-			continue
-		} else if err == nil {
-			// Webhook already exists. Update and return
-			setupLog.Info("Updating existing validatingwebhookconfiguration")
-			existingWebhook.Webhooks = validatingWebhook.Webhooks
-			err = k8sClient.Update(ctx, existingWebhook)
-			if err != nil {
-				setupLog.Error(err, "Error updating validatingwebhookconfiguration")
-				time.Sleep(5 * time.Second)
-				continue
-			}
-			return nil
-		}
-	}
-	return fmt.Errorf("unable to ensure validatingwebhook exists in allotted time")
-}
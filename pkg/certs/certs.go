@@ -0,0 +1,242 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package certs
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	// CAValidity is how long a generated CA certificate is valid for
+	CAValidity = 2 * 365 * 24 * time.Hour
+	// ServingValidity is how long a generated serving certificate is valid for
+	ServingValidity = 365 * 24 * time.Hour
+
+	// rotationFraction is the fraction of a certificate's lifetime remaining when it is rotated
+	rotationFraction = 5
+
+	keySize = 2048
+)
+
+// KeyPair is a PEM encoded certificate and its private key
+type KeyPair struct {
+	Cert []byte
+	Key  []byte
+}
+
+// NewCA generates a self-signed CA certificate valid from now until now + validity
+func NewCA(commonName string, now time.Time, validity time.Duration) (KeyPair, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return KeyPair{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("%s@%d", commonName, now.Unix())},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	return encode(der, key), nil
+}
+
+// NewServingCert generates a serving certificate for dnsNames signed by ca, valid from now until
+// now + validity
+func NewServingCert(ca KeyPair, dnsNames []string, now time.Time, validity time.Duration) (KeyPair, error) {
+	caCert, caKey, err := decode(ca)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to generate serving key: %w", err)
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return KeyPair{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to create serving certificate: %w", err)
+	}
+	return encode(der, key), nil
+}
+
+// RotationTime returns the time after which the PEM encoded certificate should be replaced. This
+// is when less than a fifth of its lifetime remains.
+func RotationTime(certPEM []byte) (time.Time, error) {
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return time.Time{}, err
+	}
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotAfter.Add(-lifetime / rotationFraction), nil
+}
+
+// ValidServingCert returns nil if the serving certificate is signed by the CA, matches its key and
+// covers all dnsNames at the given time
+func ValidServingCert(serving KeyPair, caPEM []byte, dnsNames []string, now time.Time) error {
+	if _, _, err := decode(serving); err != nil {
+		return err
+	}
+	cert, err := parseCert(serving.Cert)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("no valid CA certificates found")
+	}
+	for _, name := range dnsNames {
+		_, err := cert.Verify(x509.VerifyOptions{
+			DNSName:     name,
+			Roots:       pool,
+			CurrentTime: now,
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bundle concatenates the certificates in the given PEM data, skipping any that are expired at the
+// given time or duplicated
+func Bundle(now time.Time, pems ...[]byte) []byte {
+	bundle := []byte{}
+	for _, rest := range pems {
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil || now.After(cert.NotAfter) {
+				continue
+			}
+			encoded := pem.EncodeToMemory(block)
+			if bytes.Contains(bundle, encoded) {
+				continue
+			}
+			bundle = append(bundle, encoded...)
+		}
+	}
+	return bundle
+}
+
+// WriteFiles writes the serving certificate into dir using the file names expected by the webhook
+// server. Files are only rewritten when their content changes.
+func WriteFiles(dir string, serving KeyPair) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	files := map[string][]byte{"tls.crt": serving.Cert, "tls.key": serving.Key}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// ServiceCAAvailable returns true if the OpenShift service-ca operator is installed and can issue
+// serving certificates
+func ServiceCAAvailable(dc discovery.DiscoveryInterface) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion("operator.openshift.io/v1")
+	if err != nil {
+		if discovery.IsGroupDiscoveryFailedError(err) || apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == "servicecas" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
+
+func encode(der []byte, key *rsa.PrivateKey) KeyPair {
+	return KeyPair{
+		Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Key:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	}
+}
+
+func decode(kp KeyPair) (*x509.Certificate, *rsa.PrivateKey, error) {
+	cert, err := parseCert(kp.Cert)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(kp.Key)
+	if block == nil {
+		return nil, nil, fmt.Errorf("failed to decode private key PEM")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, nil, fmt.Errorf("private key does not match certificate")
+	}
+	return cert, key, nil
+}
+
+func parseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return cert, nil
+}
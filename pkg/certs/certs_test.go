@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package certs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var dnsNames = []string{"webhook-service", "webhook-service.test.svc"}
+
+func Test_ServingCert(t *testing.T) {
+	now := time.Now()
+	ca, err := NewCA("test", now, CAValidity)
+	if err != nil {
+		t.Fatalf("NewCA() error = %v", err)
+	}
+	serving, err := NewServingCert(ca, dnsNames, now, ServingValidity)
+	if err != nil {
+		t.Fatalf("NewServingCert() error = %v", err)
+	}
+
+	t.Run("valid serving certificate", func(t *testing.T) {
+		if err := ValidServingCert(serving, ca.Cert, dnsNames, now); err != nil {
+			t.Errorf("ValidServingCert() error = %v", err)
+		}
+	})
+
+	t.Run("unknown DNS name", func(t *testing.T) {
+		if err := ValidServingCert(serving, ca.Cert, []string{"other-service"}, now); err == nil {
+			t.Errorf("ValidServingCert() should fail for a name not in the certificate")
+		}
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+		if err := ValidServingCert(serving, ca.Cert, dnsNames, now.Add(ServingValidity+time.Hour)); err == nil {
+			t.Errorf("ValidServingCert() should fail for an expired certificate")
+		}
+	})
+
+	t.Run("signed by another CA", func(t *testing.T) {
+		other, err := NewCA("other", now, CAValidity)
+		if err != nil {
+			t.Fatalf("NewCA() error = %v", err)
+		}
+		if err := ValidServingCert(serving, other.Cert, dnsNames, now); err == nil {
+			t.Errorf("ValidServingCert() should fail for a certificate signed by another CA")
+		}
+	})
+
+	t.Run("mismatched key", func(t *testing.T) {
+		mismatched := KeyPair{Cert: serving.Cert, Key: ca.Key}
+		if err := ValidServingCert(mismatched, ca.Cert, dnsNames, now); err == nil {
+			t.Errorf("ValidServingCert() should fail when the key does not match the certificate")
+		}
+	})
+}
+
+func Test_RotationTime(t *testing.T) {
+	now := time.Now()
+	ca, err := NewCA("test", now, CAValidity)
+	if err != nil {
+		t.Fatalf("NewCA() error = %v", err)
+	}
+	rotation, err := RotationTime(ca.Cert)
+	if err != nil {
+		t.Fatalf("RotationTime() error = %v", err)
+	}
+	if !rotation.After(now.Add(CAValidity/2)) || !rotation.Before(now.Add(CAValidity)) {
+		t.Errorf("RotationTime() = %v, want a time in the last half of the certificate lifetime", rotation)
+	}
+	if _, err := RotationTime([]byte("not a certificate")); err == nil {
+		t.Errorf("RotationTime() should fail for invalid PEM data")
+	}
+}
+
+func Test_Bundle(t *testing.T) {
+	now := time.Now()
+	current, err := NewCA("current", now, CAValidity)
+	if err != nil {
+		t.Fatalf("NewCA() error = %v", err)
+	}
+	previous, err := NewCA("previous", now.Add(-CAValidity), CAValidity+time.Hour)
+	if err != nil {
+		t.Fatalf("NewCA() error = %v", err)
+	}
+	expired, err := NewCA("expired", now.Add(-2*CAValidity), CAValidity)
+	if err != nil {
+		t.Fatalf("NewCA() error = %v", err)
+	}
+
+	bundle := Bundle(now, current.Cert, append(previous.Cert, expired.Cert...), current.Cert)
+	if !bytes.Contains(bundle, current.Cert) || !bytes.Contains(bundle, previous.Cert) {
+		t.Errorf("Bundle() should contain the current and previous CA")
+	}
+	if bytes.Contains(bundle, expired.Cert) {
+		t.Errorf("Bundle() should not contain expired certificates")
+	}
+	if bytes.Count(bundle, current.Cert) != 1 {
+		t.Errorf("Bundle() should not contain duplicate certificates")
+	}
+}
+
+func Test_WriteFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "certs")
+	kp := KeyPair{Cert: []byte("cert"), Key: []byte("key")}
+	if err := WriteFiles(dir, kp); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+	for name, want := range map[string][]byte{"tls.crt": kp.Cert, "tls.key": kp.Key} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("WriteFiles() %s = %s, want %s", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/manifests"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Diff implements the diff subcommand, which compares the manifests the operator would apply for a
+// MultiClusterEngine against the live resources on a cluster. It returns an error if any resource
+// differs, so it can be used to check a hub for drift before upgrading or unpausing it.
+func Diff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags := &renderFlags{}
+	flags.bind(fs)
+	kubeconfig := fs.String("kubeconfig", "", "Path to the kubeconfig of the cluster. The in-cluster config or KUBECONFIG is used if not set.")
+	operatorNamespace := fs.String("operator-namespace", defaultOperatorNamespace(), "Namespace of the operator, where the image override configmap is read from")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	mce, imgs, err := flags.read()
+	if err != nil {
+		return err
+	}
+	c, err := newClient(*kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// Images and the proxy are resolved from the cluster the way the operator resolves them
+	ctx := context.Background()
+	imgs, err = clusterImages(ctx, c, mce, imgs, *operatorNamespace, stdout)
+	if err != nil {
+		return err
+	}
+	if err := utils.SetClusterProxy(ctx, c, flags.facts.Platform); err != nil {
+		return fmt.Errorf("failed to read cluster proxy: %w", err)
+	}
+	objs, err := manifests.Render(mce, imgs, flags.facts)
+	if err != nil {
+		return err
+	}
+
+	owner := &backplanev1.MultiClusterEngine{}
+	err = c.Get(ctx, client.ObjectKey{Name: mce.Name}, owner)
+	if apierrors.IsNotFound(err) {
+		fmt.Fprintf(stdout, "MultiClusterEngine %s not found, owner references are not compared\n", mce.Name)
+		owner = nil
+	} else if err != nil {
+		return err
+	}
+
+	diffs, err := manifests.Diff(ctx, c, owner, objs)
+	if err != nil {
+		return err
+	}
+	drifted := writeDiffs(stdout, diffs)
+	if drifted > 0 {
+		return fmt.Errorf("%d of %d resources differ from the cluster", drifted, len(diffs))
+	}
+	return nil
+}
+
+// clusterImages applies the image overrides and mirrors the operator reads from the cluster to the images
+// of the image manifest, and notes any override or image that could not be applied
+func clusterImages(ctx context.Context, c client.Client, mce *backplanev1.MultiClusterEngine, imgs map[string]string, operatorNamespace string, w io.Writer) (map[string]string, error) {
+	imgs, issues, err := images.OverrideImages(ctx, c, mce, imgs, operatorNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image overrides: %w", err)
+	}
+	if len(issues.Invalid) > 0 {
+		fmt.Fprintf(w, "Invalid image overrides are skipped: %s\n", strings.Join(issues.Invalid, ", "))
+	}
+	if len(issues.Unknown) > 0 {
+		fmt.Fprintf(w, "Image overrides match no image: %s\n", strings.Join(issues.Unknown, ", "))
+	}
+
+	if !utils.ShouldResolveImageMirrors(mce) {
+		return imgs, nil
+	}
+	mirrors, err := images.GetMirrors(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image mirrors: %w", err)
+	}
+	imgs, unmirrored := images.ResolveMirrors(imgs, mirrors)
+	if len(unmirrored) > 0 {
+		fmt.Fprintf(w, "No mirror found for images: %s\n", strings.Join(unmirrored, ", "))
+	}
+	return imgs, nil
+}
+
+// defaultOperatorNamespace returns the namespace of the operator pod when run inside it, or the default
+// target namespace the operator is usually installed in
+func defaultOperatorNamespace() string {
+	if ns, ok := os.LookupEnv("POD_NAMESPACE"); ok {
+		return ns
+	}
+	return backplanev1.DefaultTargetNamespace
+}
+
+// writeDiffs writes the resources that differ from the cluster and returns how many there are
+func writeDiffs(w io.Writer, diffs []manifests.ObjectDiff) int {
+	drifted := 0
+	for _, d := range diffs {
+		if !d.Drifted() {
+			continue
+		}
+		drifted++
+		name := d.Object.GetName()
+		if d.Object.GetNamespace() != "" {
+			name = d.Object.GetNamespace() + "/" + name
+		}
+		if d.Missing {
+			fmt.Fprintf(w, "=== %s %s: missing\n", d.Object.GetKind(), name)
+			continue
+		}
+		fmt.Fprintf(w, "=== %s %s\n", d.Object.GetKind(), name)
+		for _, c := range d.Conflicts {
+			fmt.Fprintf(w, "! %s is owned by %s\n", c.Field, c.Manager)
+		}
+		fmt.Fprint(w, d.Diff)
+	}
+	fmt.Fprintf(w, "%d of %d resources differ from the cluster\n", drifted, len(diffs))
+	return drifted
+}
+
+func newRESTConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return ctrl.GetConfig()
+}
+
+func newClient(kubeconfig string) (client.Client, error) {
+	cfg, err := newRESTConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := backplanev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	// Read for the cluster proxy and image mirrors
+	if err := configv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := operatorv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
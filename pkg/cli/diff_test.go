@@ -0,0 +1,78 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_clusterImages(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	configv1.AddToScheme(scheme)
+	operatorv1alpha1.AddToScheme(scheme)
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	overrides := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "operator-ns"},
+		Data: map[string]string{
+			"overrides.json": `[{"image-key": "discovery_operator", "image-name": "discovery-operator", "image-remote": "quay.io/stolostron", "image-digest": "` + digest + `"}]`,
+		},
+	}
+	idms := &configv1.ImageDigestMirrorSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "idms"},
+		Spec: configv1.ImageDigestMirrorSetSpec{
+			ImageDigestMirrors: []configv1.ImageDigestMirrors{
+				{Source: "quay.io/stolostron", Mirrors: []configv1.ImageMirror{"mirror.example.com/stolostron"}},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(overrides, idms).Build()
+
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "multiclusterengine",
+			Annotations: map[string]string{
+				utils.AnnotationImageOverridesCM:    "overrides",
+				utils.AnnotationResolveImageMirrors: "true",
+			},
+		},
+	}
+	imgs := map[string]string{
+		"discovery_operator": "quay.io/stolostron/discovery-operator:2.4",
+		"openshift_hive":     "quay.io/stolostron/hive:2.4",
+	}
+
+	out := &bytes.Buffer{}
+	got, err := clusterImages(context.TODO(), cl, mce, imgs, "operator-ns", out)
+	if err != nil {
+		t.Fatalf("clusterImages() error = %v", err)
+	}
+	want := map[string]string{
+		"discovery_operator": "mirror.example.com/stolostron/discovery-operator@" + digest,
+		"openshift_hive":     "quay.io/stolostron/hive:2.4",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("clusterImages() = %v, want %v", got, want)
+	}
+	if !strings.Contains(out.String(), "No mirror found for images: openshift_hive") {
+		t.Errorf("expected the unmirrored image to be noted, got %q", out.String())
+	}
+
+	if _, err := clusterImages(context.TODO(), cl, mce, imgs, "other-ns", out); err == nil {
+		t.Error("expected an error when the override configmap is not found")
+	}
+}
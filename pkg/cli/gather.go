@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/stolostron/backplane-operator/pkg/gather"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Gather implements the gather subcommand, which writes a diagnostics tarball of the MultiClusterEngines
+// on a cluster and the resources the operator manages for them
+func Gather(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gather", flag.ContinueOnError)
+	kubeconfig := fs.String("kubeconfig", "", "Path to the kubeconfig of the cluster. The in-cluster config or KUBECONFIG is used if not set.")
+	operatorNamespace := fs.String("operator-namespace", "multicluster-engine", "Namespace the operator runs in")
+	output := fs.String("output", "", "Path of the tarball to write, or - for stdout. Defaults to a timestamped file in the working directory.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := newRESTConfig(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	c, err := newClient(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	g := &gather.Gatherer{Client: c, Clientset: clientset, OperatorNamespace: *operatorNamespace}
+
+	if *output == "-" {
+		return g.Gather(context.Background(), stdout)
+	}
+	if *output == "" {
+		*output = gather.FileName(time.Now())
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := g.Gather(context.Background(), f); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s\n", *output)
+	return f.Close()
+}
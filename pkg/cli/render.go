@@ -0,0 +1,150 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package cli implements the subcommands of the operator binary that run without starting the operator
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/manifests"
+	"github.com/stolostron/backplane-operator/pkg/platform"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// renderFlags are the inputs shared by subcommands that render manifests offline
+type renderFlags struct {
+	mceFile    string
+	imagesFile string
+	facts      manifests.Facts
+}
+
+func (f *renderFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.mceFile, "mce", "", "Path to the MultiClusterEngine YAML (required)")
+	fs.StringVar(&f.imagesFile, "images", "", "Path to the image manifest, a JSON or YAML list of images (required)")
+	fs.StringVar(&f.facts.Platform, "platform", platform.OpenShift, "Platform of the cluster, OpenShift or Kubernetes")
+	fs.StringVar(&f.facts.OCPVersion, "ocp-version", "", "OpenShift version of the cluster (required on OpenShift)")
+	fs.StringVar(&f.facts.IngressDomain, "ingress-domain", "", "Ingress domain of the cluster")
+	fs.StringVar(&f.facts.HubType, "hub-type", string(utils.HubTypeMCE), "Hub type, one of mce, acm, stolostron-engine or stolostron")
+}
+
+// read reads the MultiClusterEngine and the images from the image manifest
+func (f *renderFlags) read() (*backplanev1.MultiClusterEngine, map[string]string, error) {
+	if f.mceFile == "" || f.imagesFile == "" {
+		return nil, nil, fmt.Errorf("--mce and --images are required")
+	}
+	if f.facts.Platform == platform.OpenShift && f.facts.OCPVersion == "" {
+		return nil, nil, fmt.Errorf("--ocp-version is required on OpenShift")
+	}
+
+	mce, err := readMultiClusterEngine(f.mceFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(f.imagesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	imgs, err := images.ImagesFromManifest(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid image manifest %s: %w", f.imagesFile, err)
+	}
+	return mce, imgs, nil
+}
+
+// render reads the inputs and renders the manifests. Of the image overrides, only the image repository
+// annotation is applied, since the override configmap and image mirrors are read from a cluster.
+func (f *renderFlags) render() ([]*unstructured.Unstructured, error) {
+	mce, imgs, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	if imageRepo := utils.GetImageRepository(mce); imageRepo != "" {
+		imgs = images.OverrideImageRepository(imgs, imageRepo)
+	}
+	return manifests.Render(mce, imgs, f.facts)
+}
+
+// Render implements the render subcommand, which writes every manifest the operator would apply for a
+// MultiClusterEngine to stdout or a directory
+func Render(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags := &renderFlags{}
+	flags.bind(fs)
+	outputDir := fs.String("output-dir", "", "Directory to write one file per manifest to. Manifests are written to stdout if not set.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	objs, err := flags.render()
+	if err != nil {
+		return err
+	}
+	if *outputDir == "" {
+		return writeManifests(stdout, objs)
+	}
+	return writeManifestDir(*outputDir, objs)
+}
+
+func readMultiClusterEngine(path string) (*backplanev1.MultiClusterEngine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mce := &backplanev1.MultiClusterEngine{}
+	if err := yaml.UnmarshalStrict(data, mce); err != nil {
+		return nil, fmt.Errorf("invalid MultiClusterEngine %s: %w", path, err)
+	}
+	if mce.Kind != "MultiClusterEngine" {
+		return nil, fmt.Errorf("%s is a %q, not a MultiClusterEngine", path, mce.Kind)
+	}
+	return mce, nil
+}
+
+// writeManifests writes the objects as a multi-document YAML stream
+func writeManifests(w io.Writer, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeManifestDir writes each object to its own file, prefixed with its position in apply order
+func writeManifestDir(dir string, objs []*unstructured.Unstructured) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for i, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, manifestFileName(i, obj)), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func manifestFileName(i int, obj *unstructured.Unstructured) string {
+	parts := []string{fmt.Sprintf("%03d", i), strings.ToLower(obj.GetKind())}
+	if obj.GetNamespace() != "" {
+		parts = append(parts, obj.GetNamespace())
+	}
+	parts = append(parts, obj.GetName())
+	return strings.ReplaceAll(strings.Join(parts, "_"), ":", "-") + ".yaml"
+}
@@ -0,0 +1,80 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stolostron/backplane-operator/pkg/utils"
+)
+
+const testMCE = `apiVersion: multicluster.openshift.io/v1
+kind: MultiClusterEngine
+metadata:
+  name: multiclusterengine
+spec:
+  targetNamespace: multicluster-engine
+`
+
+// writeInputs writes the MultiClusterEngine and image manifest and returns their paths
+func writeInputs(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	mceFile, imagesFile := filepath.Join(dir, "mce.yaml"), filepath.Join(dir, "images.yaml")
+	if err := os.WriteFile(mceFile, []byte(testMCE), 0600); err != nil {
+		t.Fatal(err)
+	}
+	manifest := ""
+	for _, k := range utils.GetTestImages() {
+		manifest += fmt.Sprintf("- {image-key: %s, image-name: %s, image-remote: quay.io/stolostron, image-tag: \"2.4\"}\n", k, strings.ReplaceAll(k, "_", "-"))
+	}
+	if err := os.WriteFile(imagesFile, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return mceFile, imagesFile
+}
+
+func setTestEnv(t *testing.T) {
+	t.Setenv("DIRECTORY_OVERRIDE", "../../")
+	for _, key := range []string{"ACM_HUB_PLATFORM", "ACM_HUB_OCP_VERSION", "ACM_CLUSTER_INGRESS_DOMAIN", "OPERATOR_PACKAGE"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestRender(t *testing.T) {
+	setTestEnv(t)
+	mceFile, imagesFile := writeInputs(t)
+
+	out := &bytes.Buffer{}
+	err := Render([]string{"--mce", mceFile, "--images", imagesFile, "--ocp-version", "4.14.0"}, out)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "image: quay.io/stolostron/discovery-operator:2.4") {
+		t.Error("expected the discovery operator deployment in the output")
+	}
+
+	dir := filepath.Join(t.TempDir(), "out")
+	err = Render([]string{"--mce", mceFile, "--images", imagesFile, "--platform", "Kubernetes", "--output-dir", dir}, out)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil || len(files) == 0 {
+		t.Fatalf("expected manifests in %s: %v", dir, err)
+	}
+	if !strings.HasPrefix(files[0].Name(), "000_customresourcedefinition_") {
+		t.Errorf("expected a CRD first, got %s", files[0].Name())
+	}
+
+	if err := Render([]string{"--mce", mceFile, "--images", imagesFile}, out); err == nil {
+		t.Error("expected an error without an OCP version on OpenShift")
+	}
+	if err := Render([]string{"--mce", imagesFile, "--images", imagesFile, "--ocp-version", "4.14.0"}, out); err == nil {
+		t.Error("expected an error when the MultiClusterEngine is invalid")
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// Validate implements the validate subcommand, which checks a MultiClusterEngine, and optionally an
+// update from a previous version of it, against the rules of the admission webhook that do not depend
+// on the state of the cluster
+func Validate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	mceFile := fs.String("mce", "", "Path to the MultiClusterEngine YAML (required)")
+	oldFile := fs.String("old", "", "Path to the previous version of the MultiClusterEngine, to validate the change as an update")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *mceFile == "" {
+		return fmt.Errorf("--mce is required")
+	}
+
+	mce, err := readMultiClusterEngine(*mceFile)
+	if err != nil {
+		return err
+	}
+	// Defaults are set by the mutating webhook before validation
+	mce.Default()
+
+	if *oldFile == "" {
+		err = mce.ValidateSpec()
+	} else {
+		old, readErr := readMultiClusterEngine(*oldFile)
+		if readErr != nil {
+			return readErr
+		}
+		err = mce.ValidateSpecUpdate(old)
+	}
+	if err != nil {
+		return fmt.Errorf("%s is invalid: %w", *mceFile, err)
+	}
+	fmt.Fprintf(stdout, "%s is valid\n", *mceFile)
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	valid := write("valid.yaml", testMCE)
+	defaulted := write("defaulted.yaml", `apiVersion: multicluster.openshift.io/v1
+kind: MultiClusterEngine
+metadata:
+  name: multiclusterengine
+`)
+	moved := write("moved.yaml", `apiVersion: multicluster.openshift.io/v1
+kind: MultiClusterEngine
+metadata:
+  name: multiclusterengine
+spec:
+  targetNamespace: other
+`)
+	unknownComponent := write("component.yaml", `apiVersion: multicluster.openshift.io/v1
+kind: MultiClusterEngine
+metadata:
+  name: multiclusterengine
+spec:
+  overrides:
+    components:
+    - name: fake-component
+      enabled: true
+`)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "Valid", args: []string{"--mce", valid}},
+		{name: "Valid update", args: []string{"--mce", valid, "--old", defaulted}},
+		{name: "Unknown component", args: []string{"--mce", unknownComponent}, wantErr: backplanev1.ErrInvalidComponent},
+		{name: "Target namespace changed", args: []string{"--mce", moved, "--old", valid}, wantErr: backplanev1.ErrInvalidNamespace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.args, &bytes.Buffer{})
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
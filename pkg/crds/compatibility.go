@@ -0,0 +1,137 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package crds
+
+import (
+	"fmt"
+	"strings"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Compatibility describes how applying a desired CRD would affect the versions of an existing CRD
+type Compatibility struct {
+	// RemovedStoredVersions are versions listed in status.storedVersions that the desired CRD
+	// no longer defines. Objects may still be persisted in these versions, so applying is unsafe.
+	RemovedStoredVersions []string
+	// UnservedVersions are versions currently served that the desired CRD no longer serves.
+	// Clients using these versions will stop working, but no data is lost.
+	UnservedVersions []string
+	// CurrentStorageVersion is the storage version of the existing CRD
+	CurrentStorageVersion string
+	// DesiredStorageVersion is the storage version of the desired CRD
+	DesiredStorageVersion string
+}
+
+// Safe returns true if the desired CRD can be applied without orphaning stored objects
+func (c Compatibility) Safe() bool {
+	return len(c.RemovedStoredVersions) == 0
+}
+
+// StorageVersionChanged returns true if the desired CRD persists objects in a different version
+func (c Compatibility) StorageVersionChanged() bool {
+	return c.CurrentStorageVersion != c.DesiredStorageVersion
+}
+
+// Warnings returns human-readable descriptions of the compatibility issues found
+func (c Compatibility) Warnings() []string {
+	warnings := []string{}
+	if len(c.RemovedStoredVersions) > 0 {
+		warnings = append(warnings, fmt.Sprintf("stored versions [%s] would be removed before being migrated",
+			strings.Join(c.RemovedStoredVersions, ", ")))
+	}
+	if len(c.UnservedVersions) > 0 {
+		warnings = append(warnings, fmt.Sprintf("versions [%s] would no longer be served",
+			strings.Join(c.UnservedVersions, ", ")))
+	}
+	return warnings
+}
+
+// CheckCompatibility compares the versions of the desired CRD against the existing CRD and its
+// status.storedVersions
+func CheckCompatibility(existing *apixv1.CustomResourceDefinition, desired *unstructured.Unstructured) (Compatibility, error) {
+	desiredCRD, err := ToTyped(desired)
+	if err != nil {
+		return Compatibility{}, err
+	}
+
+	desiredVersions := map[string]apixv1.CustomResourceDefinitionVersion{}
+	for _, v := range desiredCRD.Spec.Versions {
+		desiredVersions[v.Name] = v
+	}
+
+	c := Compatibility{
+		CurrentStorageVersion: StorageVersion(existing),
+		DesiredStorageVersion: StorageVersion(desiredCRD),
+	}
+	for _, stored := range existing.Status.StoredVersions {
+		if _, ok := desiredVersions[stored]; !ok {
+			c.RemovedStoredVersions = append(c.RemovedStoredVersions, stored)
+		}
+	}
+	for _, v := range existing.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		if dv, ok := desiredVersions[v.Name]; !ok || !dv.Served {
+			c.UnservedVersions = append(c.UnservedVersions, v.Name)
+		}
+	}
+	return c, nil
+}
+
+// IntermediateCRD returns the desired CRD with the stored versions it removes added back from the
+// existing CRD as served, non-storage versions. Applying it moves storage to the desired version while
+// objects persisted in the removed versions can still be read, so they can be migrated before the
+// desired CRD drops those versions.
+func IntermediateCRD(existing *apixv1.CustomResourceDefinition, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	compat, err := CheckCompatibility(existing, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	intermediate := desired.DeepCopy()
+	desiredVersions, _, err := unstructured.NestedSlice(intermediate.Object, "spec", "versions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read versions of CRD '%s': %w", desired.GetName(), err)
+	}
+	for _, removed := range compat.RemovedStoredVersions {
+		for _, v := range existing.Spec.Versions {
+			if v.Name != removed {
+				continue
+			}
+			v.Served = true
+			v.Storage = false
+			version, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert version '%s' of CRD '%s': %w", v.Name, desired.GetName(), err)
+			}
+			desiredVersions = append(desiredVersions, version)
+		}
+	}
+	if err := unstructured.SetNestedSlice(intermediate.Object, desiredVersions, "spec", "versions"); err != nil {
+		return nil, fmt.Errorf("failed to set versions of CRD '%s': %w", desired.GetName(), err)
+	}
+	return intermediate, nil
+}
+
+// StorageVersion returns the name of the version marked as the storage version of the CRD
+func StorageVersion(crd *apixv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// ToTyped converts an unstructured CRD into its typed representation
+func ToTyped(u *unstructured.Unstructured) (*apixv1.CustomResourceDefinition, error) {
+	crd := &apixv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil, fmt.Errorf("failed to convert CRD '%s': %w", u.GetName(), err)
+	}
+	return crd, nil
+}
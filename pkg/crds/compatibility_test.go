@@ -0,0 +1,159 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package crds
+
+import (
+	"reflect"
+	"testing"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func versions(vs ...apixv1.CustomResourceDefinitionVersion) []apixv1.CustomResourceDefinitionVersion {
+	return vs
+}
+
+func toUnstructured(t *testing.T, crd *apixv1.CustomResourceDefinition) *unstructured.Unstructured {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+	if err != nil {
+		t.Fatalf("failed to convert CRD: %v", err)
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func Test_CheckCompatibility(t *testing.T) {
+	v1beta1 := apixv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true}
+	v1beta1Storage := apixv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true, Storage: true}
+	v1beta1Unserved := apixv1.CustomResourceDefinitionVersion{Name: "v1beta1"}
+	v1 := apixv1.CustomResourceDefinitionVersion{Name: "v1", Served: true}
+	v1Storage := apixv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+
+	tests := []struct {
+		name            string
+		existing        []apixv1.CustomResourceDefinitionVersion
+		storedVersions  []string
+		desired         []apixv1.CustomResourceDefinitionVersion
+		want            Compatibility
+		wantSafe        bool
+		wantStorageMove bool
+	}{
+		{
+			name:           "unchanged versions",
+			existing:       versions(v1Storage),
+			storedVersions: []string{"v1"},
+			desired:        versions(v1Storage),
+			want:           Compatibility{CurrentStorageVersion: "v1", DesiredStorageVersion: "v1"},
+			wantSafe:       true,
+		},
+		{
+			name:            "storage version changed",
+			existing:        versions(v1beta1Storage, v1),
+			storedVersions:  []string{"v1beta1"},
+			desired:         versions(v1beta1, v1Storage),
+			want:            Compatibility{CurrentStorageVersion: "v1beta1", DesiredStorageVersion: "v1"},
+			wantSafe:        true,
+			wantStorageMove: true,
+		},
+		{
+			name:           "stored version removed",
+			existing:       versions(v1beta1, v1Storage),
+			storedVersions: []string{"v1beta1", "v1"},
+			desired:        versions(v1Storage),
+			want: Compatibility{
+				RemovedStoredVersions: []string{"v1beta1"},
+				UnservedVersions:      []string{"v1beta1"},
+				CurrentStorageVersion: "v1",
+				DesiredStorageVersion: "v1",
+			},
+			wantSafe: false,
+		},
+		{
+			name:           "migrated version no longer served",
+			existing:       versions(v1beta1, v1Storage),
+			storedVersions: []string{"v1"},
+			desired:        versions(v1beta1Unserved, v1Storage),
+			want: Compatibility{
+				UnservedVersions:      []string{"v1beta1"},
+				CurrentStorageVersion: "v1",
+				DesiredStorageVersion: "v1",
+			},
+			wantSafe: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &apixv1.CustomResourceDefinition{
+				Spec:   apixv1.CustomResourceDefinitionSpec{Versions: tt.existing},
+				Status: apixv1.CustomResourceDefinitionStatus{StoredVersions: tt.storedVersions},
+			}
+			desired := toUnstructured(t, &apixv1.CustomResourceDefinition{
+				Spec: apixv1.CustomResourceDefinitionSpec{Versions: tt.desired},
+			})
+
+			got, err := CheckCompatibility(existing, desired)
+			if err != nil {
+				t.Fatalf("CheckCompatibility() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckCompatibility() = %+v, want %+v", got, tt.want)
+			}
+			if got.Safe() != tt.wantSafe {
+				t.Errorf("Compatibility.Safe() = %v, want %v", got.Safe(), tt.wantSafe)
+			}
+			if got.StorageVersionChanged() != tt.wantStorageMove {
+				t.Errorf("Compatibility.StorageVersionChanged() = %v, want %v", got.StorageVersionChanged(), tt.wantStorageMove)
+			}
+		})
+	}
+}
+
+func Test_IntermediateCRD(t *testing.T) {
+	existing := &apixv1.CustomResourceDefinition{
+		Spec: apixv1.CustomResourceDefinitionSpec{Versions: versions(
+			apixv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true, Storage: true},
+		)},
+		Status: apixv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1beta1"}},
+	}
+	desired := toUnstructured(t, &apixv1.CustomResourceDefinition{
+		Spec: apixv1.CustomResourceDefinitionSpec{Versions: versions(
+			apixv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+		)},
+	})
+
+	got, err := IntermediateCRD(existing, desired)
+	if err != nil {
+		t.Fatalf("IntermediateCRD() error = %v", err)
+	}
+	intermediate, err := ToTyped(got)
+	if err != nil {
+		t.Fatalf("ToTyped() error = %v", err)
+	}
+	want := versions(
+		apixv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+		apixv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true},
+	)
+	if !reflect.DeepEqual(intermediate.Spec.Versions, want) {
+		t.Errorf("IntermediateCRD() versions = %+v, want %+v", intermediate.Spec.Versions, want)
+	}
+
+	// The intermediate CRD can be applied, and moves storage to the desired version
+	compat, err := CheckCompatibility(existing, got)
+	if err != nil {
+		t.Fatalf("CheckCompatibility() error = %v", err)
+	}
+	if !compat.Safe() || !compat.StorageVersionChanged() {
+		t.Errorf("expected intermediate CRD to be safe and change the storage version, got %+v", compat)
+	}
+
+	// Once migrated, the desired CRD can be applied over the intermediate CRD
+	intermediate.Status.StoredVersions = []string{"v1"}
+	compat, err = CheckCompatibility(intermediate, desired)
+	if err != nil {
+		t.Fatalf("CheckCompatibility() error = %v", err)
+	}
+	if !compat.Safe() {
+		t.Errorf("expected desired CRD to be safe after migration, got %+v", compat)
+	}
+}
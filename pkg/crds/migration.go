@@ -0,0 +1,81 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package crds
+
+import (
+	"fmt"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MigrationGVK is the GroupVersionKind of the kube-storage-version-migrator's migration resource
+var MigrationGVK = schema.GroupVersionKind{
+	Group:   "migration.k8s.io",
+	Version: "v1alpha1",
+	Kind:    "StorageVersionMigration",
+}
+
+// MigrationState describes the progress of a StorageVersionMigration
+type MigrationState string
+
+const (
+	// MigrationRunning means the migration has not finished yet
+	MigrationRunning MigrationState = "Running"
+	// MigrationSucceeded means every object has been rewritten in the storage version
+	MigrationSucceeded MigrationState = "Succeeded"
+	// MigrationFailed means the migrator gave up on the migration
+	MigrationFailed MigrationState = "Failed"
+)
+
+// NeedsMigration returns true if objects of the CRD may be persisted in a version other than its
+// current storage version
+func NeedsMigration(crd *apixv1.CustomResourceDefinition) bool {
+	storage := StorageVersion(crd)
+	for _, v := range crd.Status.StoredVersions {
+		if v != storage {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrationName returns the name of the StorageVersionMigration for the CRD's current storage version
+func MigrationName(crd *apixv1.CustomResourceDefinition) string {
+	return fmt.Sprintf("%s-%s", crd.GetName(), StorageVersion(crd))
+}
+
+// NewStorageVersionMigration returns a StorageVersionMigration that rewrites every object of the
+// CRD in its current storage version
+func NewStorageVersionMigration(crd *apixv1.CustomResourceDefinition) *unstructured.Unstructured {
+	m := &unstructured.Unstructured{}
+	m.SetGroupVersionKind(MigrationGVK)
+	m.SetName(MigrationName(crd))
+	m.Object["spec"] = map[string]interface{}{
+		"resource": map[string]interface{}{
+			"group":    crd.Spec.Group,
+			"version":  StorageVersion(crd),
+			"resource": crd.Spec.Names.Plural,
+		},
+	}
+	return m
+}
+
+// GetMigrationState reads the progress of a StorageVersionMigration from its status conditions
+func GetMigrationState(m *unstructured.Unstructured) MigrationState {
+	conditions, _, _ := unstructured.NestedSlice(m.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["status"] != "True" {
+			continue
+		}
+		switch cond["type"] {
+		case string(MigrationSucceeded):
+			return MigrationSucceeded
+		case string(MigrationFailed):
+			return MigrationFailed
+		}
+	}
+	return MigrationRunning
+}
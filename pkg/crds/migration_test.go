@@ -0,0 +1,109 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package crds
+
+import (
+	"testing"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_NeedsMigration(t *testing.T) {
+	crd := &apixv1.CustomResourceDefinition{
+		Spec: apixv1.CustomResourceDefinitionSpec{
+			Versions: versions(
+				apixv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true},
+				apixv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+			),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		storedVersions []string
+		want           bool
+	}{
+		{name: "only storage version stored", storedVersions: []string{"v1"}, want: false},
+		{name: "old version stored", storedVersions: []string{"v1beta1", "v1"}, want: true},
+		{name: "only old version stored", storedVersions: []string{"v1beta1"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crd.Status.StoredVersions = tt.storedVersions
+			if got := NeedsMigration(crd); got != tt.want {
+				t.Errorf("NeedsMigration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NewStorageVersionMigration(t *testing.T) {
+	crd := &apixv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.test.io"},
+		Spec: apixv1.CustomResourceDefinitionSpec{
+			Group: "test.io",
+			Names: apixv1.CustomResourceDefinitionNames{Plural: "widgets"},
+			Versions: versions(
+				apixv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+			),
+		},
+	}
+
+	m := NewStorageVersionMigration(crd)
+	if m.GetName() != "widgets.test.io-v1" {
+		t.Errorf("NewStorageVersionMigration() name = %s, want widgets.test.io-v1", m.GetName())
+	}
+	if m.GroupVersionKind() != MigrationGVK {
+		t.Errorf("NewStorageVersionMigration() gvk = %v, want %v", m.GroupVersionKind(), MigrationGVK)
+	}
+	resource, _, _ := unstructured.NestedStringMap(m.Object, "spec", "resource")
+	want := map[string]string{"group": "test.io", "version": "v1", "resource": "widgets"}
+	for k, v := range want {
+		if resource[k] != v {
+			t.Errorf("NewStorageVersionMigration() spec.resource.%s = %s, want %s", k, resource[k], v)
+		}
+	}
+}
+
+func Test_GetMigrationState(t *testing.T) {
+	withConditions := func(conditions ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"conditions": conditions},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		migration *unstructured.Unstructured
+		want      MigrationState
+	}{
+		{name: "no status", migration: &unstructured.Unstructured{Object: map[string]interface{}{}}, want: MigrationRunning},
+		{
+			name:      "running",
+			migration: withConditions(map[string]interface{}{"type": "Running", "status": "True"}),
+			want:      MigrationRunning,
+		},
+		{
+			name: "succeeded",
+			migration: withConditions(
+				map[string]interface{}{"type": "Running", "status": "False"},
+				map[string]interface{}{"type": "Succeeded", "status": "True"},
+			),
+			want: MigrationSucceeded,
+		},
+		{
+			name:      "failed",
+			migration: withConditions(map[string]interface{}{"type": "Failed", "status": "True"}),
+			want:      MigrationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetMigrationState(tt.migration); got != tt.want {
+				t.Errorf("GetMigrationState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -38,6 +38,11 @@ const (
 	ClusterManagementAddonKind    = "ClusterManagementAddOn"
 )
 
+// ImageKeys are the keys of the images the ClusterManager is built from
+func ImageKeys() []string {
+	return []string{RegistrationImageKey, WorkImageKey, PlacementImageKey, AddonManagerImageKey}
+}
+
 // RegistrationImage ...
 func RegistrationImage(overrides map[string]string) string {
 	return overrides[RegistrationImageKey]
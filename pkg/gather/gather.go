@@ -0,0 +1,247 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package gather collects the resources and logs needed to diagnose a MultiClusterEngine into a
+// gzipped tarball, with secret values redacted.
+package gather
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// operatorLabel selects the operator pods
+const operatorLabel = "control-plane"
+
+// maxLogBytes limits the size of each container log collected
+const maxLogBytes = 10 * 1024 * 1024
+
+// clusterResources are the cluster scoped resources the operator manages that are collected
+var clusterResources = []struct {
+	GVK  schema.GroupVersionKind
+	Name string
+}{
+	{GVK: schema.GroupVersionKind{Group: "operator.open-cluster-management.io", Version: "v1", Kind: "ClusterManager"}, Name: "cluster-manager"},
+	{GVK: schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "HiveConfig"}, Name: "hive"},
+	{GVK: schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1", Kind: "ManagedCluster"}, Name: utils.LocalClusterName},
+}
+
+// Gatherer collects diagnostics from a cluster
+type Gatherer struct {
+	Client client.Client
+	// Clientset reads container logs, which the controller-runtime client does not serve
+	Clientset kubernetes.Interface
+	// OperatorNamespace is the namespace the operator runs in. Operator logs are not collected if empty.
+	OperatorNamespace string
+}
+
+// Gather writes a gzipped tarball of the MultiClusterEngines, the Deployments, pods and secrets labeled
+// with each of them, events in their target namespaces, the cluster scoped resources the operator
+// manages and the logs of the operator and component pods. Errors reading individual resources are
+// recorded in errors.txt in the tarball rather than stopping the collection.
+func (g *Gatherer) Gather(ctx context.Context, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	b := &bundle{tw: tw, now: time.Now()}
+
+	g.gather(ctx, b)
+	if len(b.errs) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n"))
+	}
+
+	if b.err != nil {
+		return b.err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func (g *Gatherer) gather(ctx context.Context, b *bundle) {
+	mceList := &backplanev1.MultiClusterEngineList{}
+	if err := g.Client.List(ctx, mceList); err != nil {
+		b.errorf("listing MultiClusterEngines: %v", err)
+	}
+	for i := range mceList.Items {
+		mce := &mceList.Items[i]
+		mce.SetGroupVersionKind(backplanev1.GroupVersion.WithKind("MultiClusterEngine"))
+		b.addObject(path.Join("cluster", "multiclusterengines", mce.Name+".yaml"), mce)
+		g.gatherManaged(ctx, b, mce)
+	}
+
+	for _, resource := range clusterResources {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(resource.GVK)
+		err := g.Client.Get(ctx, client.ObjectKey{Name: resource.Name}, u)
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			b.errorf("getting %s %s: %v", resource.GVK.Kind, resource.Name, err)
+			continue
+		}
+		b.addObject(path.Join("cluster", strings.ToLower(resource.GVK.Kind)+"s", resource.Name+".yaml"), u)
+	}
+
+	if g.OperatorNamespace != "" {
+		g.gatherPods(ctx, b, g.OperatorNamespace, client.HasLabels{operatorLabel})
+	}
+}
+
+// gatherManaged collects the resources labeled with the MultiClusterEngine and the events in its target namespace
+func (g *Gatherer) gatherManaged(ctx context.Context, b *bundle, mce *backplanev1.MultiClusterEngine) {
+	selector := client.MatchingLabels{"backplaneconfig.name": mce.Name}
+
+	deployments := &unstructured.UnstructuredList{}
+	deployments.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DeploymentList"})
+	if err := g.Client.List(ctx, deployments, selector); err != nil {
+		b.errorf("listing Deployments of %s: %v", mce.Name, err)
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		b.addObject(namespacedPath(d.GetNamespace(), "deployments", d.GetName()), d)
+
+		podSelector, err := deploymentSelector(d)
+		if err != nil {
+			b.errorf("reading the selector of Deployment %s/%s: %v", d.GetNamespace(), d.GetName(), err)
+			continue
+		}
+		g.gatherPods(ctx, b, d.GetNamespace(), client.MatchingLabelsSelector{Selector: podSelector})
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := g.Client.List(ctx, secrets, selector); err != nil {
+		b.errorf("listing Secrets of %s: %v", mce.Name, err)
+	}
+	for i := range secrets.Items {
+		s := &secrets.Items[i]
+		s.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		b.addObject(namespacedPath(s.Namespace, "secrets", s.Name), s)
+	}
+
+	namespace := mce.Spec.TargetNamespace
+	if namespace == "" {
+		namespace = backplanev1.DefaultTargetNamespace
+	}
+	events := &corev1.EventList{}
+	if err := g.Client.List(ctx, events, client.InNamespace(namespace)); err != nil {
+		b.errorf("listing events in %s: %v", namespace, err)
+	} else {
+		events.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("EventList"))
+		b.addObject(path.Join("namespaces", namespace, "events.yaml"), events)
+	}
+}
+
+// gatherPods collects the pods matching the selector and the logs of their containers
+func (g *Gatherer) gatherPods(ctx context.Context, b *bundle, namespace string, selector client.ListOption) {
+	pods := &corev1.PodList{}
+	if err := g.Client.List(ctx, pods, client.InNamespace(namespace), selector); err != nil {
+		b.errorf("listing pods in %s: %v", namespace, err)
+		return
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		pod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+		b.addObject(namespacedPath(namespace, "pods", pod.Name), pod)
+		if g.Clientset == nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			g.gatherLog(ctx, b, pod, status.Name, false)
+			if status.RestartCount > 0 {
+				g.gatherLog(ctx, b, pod, status.Name, true)
+			}
+		}
+	}
+}
+
+func (g *Gatherer) gatherLog(ctx context.Context, b *bundle, pod *corev1.Pod, container string, previous bool) {
+	limit := int64(maxLogBytes)
+	opts := &corev1.PodLogOptions{Container: container, Previous: previous, LimitBytes: &limit}
+	data, err := g.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+	if err != nil {
+		b.errorf("reading logs of %s/%s container %s: %v", pod.Namespace, pod.Name, container, err)
+		return
+	}
+	name := container + ".log"
+	if previous {
+		name = container + ".previous.log"
+	}
+	b.add(path.Join("namespaces", pod.Namespace, "logs", pod.Name, name), RedactLog(data))
+}
+
+func deploymentSelector(d *unstructured.Unstructured) (labels.Selector, error) {
+	raw, found, err := unstructured.NestedMap(d.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil, fmt.Errorf("no selector: %v", err)
+	}
+	selector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, selector); err != nil {
+		return nil, err
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+func namespacedPath(namespace, resource, name string) string {
+	return path.Join("namespaces", namespace, resource, name+".yaml")
+}
+
+// bundle writes files to a tarball and records the errors met while gathering
+type bundle struct {
+	tw   *tar.Writer
+	now  time.Time
+	errs []string
+	// err is the first error writing the tarball
+	err error
+}
+
+func (b *bundle) errorf(format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Sprintf(format, args...))
+}
+
+// addObject redacts and writes the object as YAML
+func (b *bundle) addObject(name string, obj runtime.Object) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		b.errorf("converting %s: %v", name, err)
+		return
+	}
+	Redact(u)
+	data, err := yaml.Marshal(u)
+	if err != nil {
+		b.errorf("marshaling %s: %v", name, err)
+		return
+	}
+	b.add(name, data)
+}
+
+func (b *bundle) add(name string, data []byte) {
+	if b.err != nil {
+		return
+	}
+	header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: b.now}
+	if b.err = b.tw.WriteHeader(header); b.err != nil {
+		return
+	}
+	_, b.err = b.tw.Write(data)
+}
@@ -0,0 +1,152 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package gather
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGather(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	backplanev1.AddToScheme(scheme)
+
+	mceLabels := map[string]string{"backplaneconfig.name": "multiclusterengine"}
+	podLabels := map[string]string{"app": "console-mce"}
+	objs := []runtime.Object{
+		&backplanev1.MultiClusterEngine{
+			ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+			Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "mce"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "console-mce", Namespace: "mce", Labels: mceLabels},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{
+						Name: "console",
+						Env:  []corev1.EnvVar{{Name: "API_TOKEN", Value: "hunter2"}, {Name: "LOG_LEVEL", Value: "debug"}},
+					}}},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "console-mce-1", Namespace: "mce", Labels: podLabels},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "console"}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "mce"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "mce", Labels: mceLabels},
+			Data:       map[string][]byte{".dockerconfigjson": []byte("hunter2")},
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "event", Namespace: "mce"},
+			Message:    "Scaled up",
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "operator", Namespace: "operator", Labels: map[string]string{"control-plane": "backplane-operator"}},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "manager", RestartCount: 1}}},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+	g := &Gatherer{Client: cl, Clientset: kubefake.NewSimpleClientset(), OperatorNamespace: "operator"}
+
+	buf := &bytes.Buffer{}
+	if err := g.Gather(context.TODO(), buf); err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	files := readTarball(t, buf)
+
+	for _, name := range []string{
+		"cluster/multiclusterengines/multiclusterengine.yaml",
+		"namespaces/mce/deployments/console-mce.yaml",
+		"namespaces/mce/pods/console-mce-1.yaml",
+		"namespaces/mce/logs/console-mce-1/console.log",
+		"namespaces/mce/secrets/pull-secret.yaml",
+		"namespaces/mce/events.yaml",
+		"namespaces/operator/pods/operator.yaml",
+		"namespaces/operator/logs/operator/manager.log",
+		"namespaces/operator/logs/operator/manager.previous.log",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected %s in the tarball", name)
+		}
+	}
+	if _, ok := files["namespaces/mce/pods/unrelated.yaml"]; ok {
+		t.Error("expected pods not selected by a Deployment to be skipped")
+	}
+	for name, data := range files {
+		if strings.Contains(data, "hunter2") {
+			t.Errorf("expected secret values to be redacted in %s", name)
+		}
+	}
+	if secret := files["namespaces/mce/secrets/pull-secret.yaml"]; !strings.Contains(secret, "REDACTED") || strings.Contains(secret, "aHVudGVyMg") {
+		t.Errorf("expected the secret data to be redacted, got %s", secret)
+	}
+	if !strings.Contains(files["namespaces/mce/deployments/console-mce.yaml"], "value: debug") {
+		t.Error("expected values of environment variables that are not sensitive to be kept")
+	}
+}
+
+func TestRedactLog(t *testing.T) {
+	got := string(RedactLog([]byte(`GET /apis Authorization: Bearer abc.def-ghi== done`)))
+	if want := "GET /apis Authorization: Bearer REDACTED done"; got != want {
+		t.Errorf("RedactLog() = %q, want %q", got, want)
+	}
+}
+
+func readTarball(t *testing.T, r io.Reader) map[string]string {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+}
+
+func TestNewServer(t *testing.T) {
+	for addr, wantErr := range map[string]bool{
+		"127.0.0.1:8082": false,
+		"localhost:8082": false,
+		"[::1]:8082":     false,
+		":8082":          true,
+		"0.0.0.0:8082":   true,
+		"10.0.0.1:8082":  true,
+		"8082":           true,
+	} {
+		if _, err := NewServer(addr, &Gatherer{}); (err != nil) != wantErr {
+			t.Errorf("NewServer(%q) error = %v, wantErr %v", addr, err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package gather
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Handler serves a diagnostics tarball on each GET request
+func Handler(g *Gatherer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", FileName(time.Now())))
+		if err := g.Gather(req.Context(), w); err != nil {
+			// The response has started, so the error can only be logged
+			ctrl.Log.WithName("gather").Error(err, "failed to write diagnostics")
+		}
+	})
+}
+
+// FileName returns the name of a diagnostics tarball gathered at t
+func FileName(t time.Time) string {
+	return fmt.Sprintf("mce-gather-%s.tar.gz", t.UTC().Format("20060102-150405"))
+}
+
+// Path is the path diagnostics are served at
+const Path = "/debug/gather"
+
+// Server serves diagnostics at Path on a loopback address, so that the pod logs and resources they
+// contain are only reachable from inside the pod, such as through kubectl port-forward. It runs on
+// every replica of the operator.
+type Server struct {
+	addr    string
+	handler http.Handler
+}
+
+// NewServer returns a diagnostics server for addr, which must be a loopback address
+func NewServer(addr string, g *Gatherer) (*Server, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid diagnostics address %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("diagnostics address %q is not a loopback address", addr)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler(g))
+	return &Server{addr: addr, handler: mux}, nil
+}
+
+// Start serves diagnostics until ctx is done
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+	server := &http.Server{Handler: s.handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, so every replica serves its own diagnostics
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package gather
+
+import (
+	"regexp"
+)
+
+// Redacted replaces secret values in gathered resources and logs
+const Redacted = "REDACTED"
+
+var (
+	// sensitiveName matches the names of environment variables that hold secrets
+	sensitiveName = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private_?key|api_?key)`)
+	// bearerToken matches bearer tokens in logs
+	bearerToken = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// Redact replaces secret values in a resource: the data of Secrets, the last applied configuration
+// of Secrets and the values of environment variables with sensitive names
+func Redact(obj map[string]interface{}) {
+	if obj["kind"] == "Secret" {
+		for _, field := range []string{"data", "stringData"} {
+			if data, ok := obj[field].(map[string]interface{}); ok {
+				for key := range data {
+					data[key] = Redacted
+				}
+			}
+		}
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+				if _, ok := annotations["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+					annotations["kubectl.kubernetes.io/last-applied-configuration"] = Redacted
+				}
+			}
+		}
+	}
+	redactEnv(obj)
+}
+
+// redactEnv walks the object and redacts env entries with sensitive names and literal values
+func redactEnv(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if env, ok := v["env"].([]interface{}); ok {
+			for _, e := range env {
+				entry, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := entry["name"].(string)
+				if _, ok := entry["value"]; ok && sensitiveName.MatchString(name) {
+					entry["value"] = Redacted
+				}
+			}
+		}
+		for _, child := range v {
+			redactEnv(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			redactEnv(child)
+		}
+	}
+}
+
+// RedactLog replaces bearer tokens in a log
+func RedactLog(data []byte) []byte {
+	return bearerToken.ReplaceAll(data, []byte("${1}"+Redacted))
+}
@@ -4,9 +4,11 @@ package images
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
@@ -15,8 +17,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 )
 
+// digestPattern matches an image reference pinned to a sha256 digest
+var digestPattern = regexp.MustCompile(`@sha256:[a-f0-9]{64}$`)
+
 // ManifestImage contains details for a specific image version
 type ManifestImage struct {
 	ImageKey     string `json:"image-key"`
@@ -32,31 +38,54 @@ type ManifestImage struct {
 	ImageTag string `json:"image-tag"`
 }
 
-// GetImagesWithOverrides gets images from the environment, then updates them based on MCE annotations
-func GetImagesWithOverrides(kubeclient client.Client, mce *backplanev1.MultiClusterEngine) (map[string]string, error) {
-	// Get images from environment
-	images := GetImages()
+// OverrideIssues describes image override entries that were not applied or have no effect
+type OverrideIssues struct {
+	// Invalid lists the entries that failed validation and were skipped
+	Invalid []string
+	// Unknown lists the image keys that do not match any image used by the operator
+	Unknown []string
+}
+
+// Empty returns true if no issues were found
+func (o OverrideIssues) Empty() bool {
+	return len(o.Invalid) == 0 && len(o.Unknown) == 0
+}
 
+// GetImagesWithOverrides gets images from the environment, then updates them based on MCE annotations.
+// Issues found in the image override configmap are returned alongside the images.
+func GetImagesWithOverrides(kubeclient client.Client, mce *backplanev1.MultiClusterEngine) (map[string]string, OverrideIssues, error) {
+	namespace := ""
+	if utils.GetImageOverridesConfigmap(mce) != "" {
+		namespace = utils.OperatorNamespace()
+	}
+	return OverrideImages(context.TODO(), kubeclient, mce, GetImages(), namespace)
+}
+
+// OverrideImages updates images based on MCE annotations: the image repository, then the image override
+// configmap, which is read from namespace. Issues found in the configmap are returned alongside the
+// images.
+func OverrideImages(ctx context.Context, kubeclient client.Client, mce *backplanev1.MultiClusterEngine, images map[string]string, namespace string) (map[string]string, OverrideIssues, error) {
 	// Override image repository if dev annotation present
 	if imageRepo := utils.GetImageRepository(mce); imageRepo != "" {
 		images = OverrideImageRepository(images, imageRepo)
 	}
 
 	// Override individual images if dev configmap present
+	issues := OverrideIssues{}
 	if cmName := utils.GetImageOverridesConfigmap(mce); cmName != "" {
 		configmap := &corev1.ConfigMap{}
-		err := kubeclient.Get(context.TODO(), types.NamespacedName{Name: cmName, Namespace: utils.OperatorNamespace()}, configmap)
+		err := kubeclient.Get(ctx, types.NamespacedName{Name: cmName, Namespace: namespace}, configmap)
 		if err != nil {
-			return nil, err
+			return nil, issues, err
 		}
 
-		images, err = OverrideImagesWithConfigmap(images, configmap)
+		images, issues, err = OverrideImagesWithConfigmap(images, configmap)
 		if err != nil {
-			return nil, err
+			return nil, issues, err
 		}
 	}
 
-	return images, nil
+	return images, issues, nil
 }
 
 // GetImages creates an image map from the environment
@@ -100,27 +129,133 @@ func OverrideImageRepository(images map[string]string, imageRepo string) map[str
 	return images
 }
 
-// OverrideImagesWithConfigmap updates an image map with images defined in configmap
-func OverrideImagesWithConfigmap(images map[string]string, configmap *corev1.ConfigMap) (map[string]string, error) {
-	if len(configmap.Data) != 1 {
-		return nil, fmt.Errorf(fmt.Sprintf("Unexpected number of keys in configmap: %s", configmap.Name))
+// OverrideImagesWithConfigmap updates an image map with images defined in configmap. Every data key
+// holds a list of ManifestImages in JSON or YAML, and keys are applied in sorted order. Entries that
+// fail validation are skipped and reported, as are image keys not present in the original image map.
+func OverrideImagesWithConfigmap(images map[string]string, configmap *corev1.ConfigMap) (map[string]string, OverrideIssues, error) {
+	issues := OverrideIssues{}
+	if len(configmap.Data) == 0 {
+		return nil, issues, fmt.Errorf("no image overrides found in configmap: %s", configmap.Name)
 	}
 
-	for _, v := range configmap.Data {
-		var manifestImages []ManifestImage
-		err := json.Unmarshal([]byte(v), &manifestImages)
+	dataKeys := make([]string, 0, len(configmap.Data))
+	for k := range configmap.Data {
+		dataKeys = append(dataKeys, k)
+	}
+	sort.Strings(dataKeys)
+
+	known := make(map[string]bool, len(images))
+	for k := range images {
+		known[k] = true
+	}
+	unknown := map[string]bool{}
+
+	for _, dataKey := range dataKeys {
+		manifestImages, err := ParseManifestImages([]byte(configmap.Data[dataKey]))
 		if err != nil {
-			return nil, err
+			return nil, issues, fmt.Errorf("failed to parse %s in configmap %s: %w", dataKey, configmap.Name, err)
 		}
 
-		for _, manifestImage := range manifestImages {
-			if manifestImage.ImageDigest != "" {
-				images[manifestImage.ImageKey] = fmt.Sprintf("%s/%s@%s", manifestImage.ImageRemote, manifestImage.ImageName, manifestImage.ImageDigest)
-			} else if manifestImage.ImageTag != "" {
-				images[manifestImage.ImageKey] = fmt.Sprintf("%s/%s:%s", manifestImage.ImageRemote, manifestImage.ImageName, manifestImage.ImageTag)
+		for i, manifestImage := range manifestImages {
+			if err := manifestImage.Validate(); err != nil {
+				issues.Invalid = append(issues.Invalid, fmt.Sprintf("%s[%d]: %s", dataKey, i, err.Error()))
+				continue
+			}
+			if !known[manifestImage.ImageKey] {
+				unknown[manifestImage.ImageKey] = true
 			}
+			images[manifestImage.ImageKey] = manifestImage.Reference()
+		}
+	}
+
+	for k := range unknown {
+		issues.Unknown = append(issues.Unknown, k)
+	}
+	sort.Strings(issues.Unknown)
+	return images, issues, nil
+}
+
+// ParseManifestImages parses a list of ManifestImages in JSON or YAML
+func ParseManifestImages(data []byte) ([]ManifestImage, error) {
+	var manifestImages []ManifestImage
+	if err := yaml.Unmarshal(data, &manifestImages); err != nil {
+		return nil, err
+	}
+	return manifestImages, nil
+}
 
+// ImagesFromManifest builds an image map from a list of ManifestImages in JSON or YAML. Unlike
+// overrides, every entry must be valid.
+func ImagesFromManifest(data []byte) (map[string]string, error) {
+	manifestImages, err := ParseManifestImages(data)
+	if err != nil {
+		return nil, err
+	}
+	images := make(map[string]string, len(manifestImages))
+	for i, manifestImage := range manifestImages {
+		if err := manifestImage.Validate(); err != nil {
+			return nil, fmt.Errorf("image %d: %w", i, err)
 		}
+		images[manifestImage.ImageKey] = manifestImage.Reference()
 	}
 	return images, nil
 }
+
+// Validate returns an error if the image is missing a field needed to build its reference
+func (m ManifestImage) Validate() error {
+	missing := []string{}
+	if m.ImageKey == "" {
+		missing = append(missing, "image-key")
+	}
+	if m.ImageName == "" {
+		missing = append(missing, "image-name")
+	}
+	if m.ImageRemote == "" {
+		missing = append(missing, "image-remote")
+	}
+	if m.ImageDigest == "" && m.ImageTag == "" {
+		missing = append(missing, "image-digest or image-tag")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	if m.ImageDigest != "" && !strings.Contains(m.ImageDigest, ":") {
+		return fmt.Errorf("image-digest %q is not of the form <algorithm>:<hex>", m.ImageDigest)
+	}
+	return nil
+}
+
+// Reference returns the image reference, preferring the digest over the tag
+func (m ManifestImage) Reference() string {
+	if m.ImageDigest != "" {
+		return fmt.Sprintf("%s/%s@%s", m.ImageRemote, m.ImageName, m.ImageDigest)
+	}
+	return fmt.Sprintf("%s/%s:%s", m.ImageRemote, m.ImageName, m.ImageTag)
+}
+
+// ValidateImages returns an error listing the keys with no image reference and, when digests are
+// required, the keys whose reference is not pinned to a sha256 digest
+func ValidateImages(images map[string]string, keys []string, requireDigests bool) error {
+	missing, undigested := []string{}, []string{}
+	for _, k := range keys {
+		ref := images[k]
+		switch {
+		case ref == "":
+			missing = append(missing, k)
+		case requireDigests && !digestPattern.MatchString(ref):
+			undigested = append(undigested, k)
+		}
+	}
+
+	problems := []string{}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing images: %s", strings.Join(missing, ", ")))
+	}
+	if len(undigested) > 0 {
+		problems = append(problems, fmt.Sprintf("images not pinned by digest: %s", strings.Join(undigested, ", ")))
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
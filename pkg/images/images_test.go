@@ -88,11 +88,12 @@ func TestOverrideImagesWithConfigmap(t *testing.T) {
 	}
 
 	tests := []struct {
-		name      string
-		images    map[string]string
-		configmap *corev1.ConfigMap
-		want      map[string]string
-		wantErr   bool
+		name       string
+		images     map[string]string
+		configmap  *corev1.ConfigMap
+		want       map[string]string
+		wantIssues OverrideIssues
+		wantErr    bool
 	}{
 		{
 			name: "Replace image",
@@ -114,6 +115,78 @@ func TestOverrideImagesWithConfigmap(t *testing.T) {
 				"cluster_api":        "quay.io/stolostron/cluster-api:latest",
 				"discovery_operator": "quay.io/stolostron/discovery-operator@sha256:9dc4d072dcd06eda3fda19a15f4b84677fbbbde2a476b4817272cde4724f02cc",
 			},
+			wantIssues: OverrideIssues{Unknown: []string{"discovery_operator"}},
+		},
+		{
+			name: "YAML and multiple keys",
+			images: map[string]string{
+				"cluster_api":        "quay.io/stolostron/cluster-api:latest",
+				"discovery_operator": "quay.io/stolostron/discovery-operator:latest",
+			},
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Data: map[string]string{
+					"a.yaml": `
+- image-key: cluster_api
+  image-name: cluster-api
+  image-remote: quay.io/acm-d
+  image-tag: "2.4"
+`,
+					"b.json": `[{"image-key": "discovery_operator", "image-name": "discovery", "image-remote": "quay.io/acm-d", "image-tag": "2.4"}]`,
+				},
+			},
+			want: map[string]string{
+				"cluster_api":        "quay.io/acm-d/cluster-api:2.4",
+				"discovery_operator": "quay.io/acm-d/discovery:2.4",
+			},
+		},
+		{
+			name: "Invalid and unknown entries",
+			images: map[string]string{
+				"cluster_api": "quay.io/stolostron/cluster-api:latest",
+			},
+			configmap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Data: map[string]string{
+					"overrides.yaml": `
+- image-key: cluster_api
+  image-name: cluster-api
+  image-remote: quay.io/acm-d
+- image-key: cluster_api
+  image-name: cluster-api
+  image-remote: quay.io/acm-d
+  image-digest: abc
+- image-key: clustr_api
+  image-name: cluster-api
+  image-remote: quay.io/acm-d
+  image-tag: "2.4"
+`,
+				},
+			},
+			want: map[string]string{
+				"cluster_api": "quay.io/stolostron/cluster-api:latest",
+				"clustr_api":  "quay.io/acm-d/cluster-api:2.4",
+			},
+			wantIssues: OverrideIssues{
+				Invalid: []string{
+					"overrides.yaml[0]: missing image-digest or image-tag",
+					`overrides.yaml[1]: image-digest "abc" is not of the form <algorithm>:<hex>`,
+				},
+				Unknown: []string{"clustr_api"},
+			},
+		},
+		{
+			name:      "Empty configmap",
+			images:    map[string]string{},
+			configmap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}},
+			want:      nil,
+			wantErr:   true,
 		},
 		{
 			name: "Invalid configmap",
@@ -136,7 +209,7 @@ func TestOverrideImagesWithConfigmap(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := OverrideImagesWithConfigmap(tt.images, tt.configmap)
+			got, issues, err := OverrideImagesWithConfigmap(tt.images, tt.configmap)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("OverrideImagesWithConfigmap() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -144,6 +217,68 @@ func TestOverrideImagesWithConfigmap(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("OverrideImagesWithConfigmap() = %v, want %v", got, tt.want)
 			}
+			if !reflect.DeepEqual(issues, tt.wantIssues) {
+				t.Errorf("OverrideImagesWithConfigmap() issues = %v, want %v", issues, tt.wantIssues)
+			}
 		})
 	}
 }
+
+func TestValidateImages(t *testing.T) {
+	digestRef := "quay.io/stolostron/console@sha256:9dc4d072dcd06eda3fda19a15f4b84677fbbbde2a476b4817272cde4724f02cc"
+	tests := []struct {
+		name           string
+		images         map[string]string
+		keys           []string
+		requireDigests bool
+		wantErr        string
+	}{
+		{
+			name:   "All images present",
+			images: map[string]string{"console": "quay.io/stolostron/console:latest"},
+			keys:   []string{"console"},
+		},
+		{
+			name:    "Missing image",
+			images:  map[string]string{"console": "quay.io/stolostron/console:latest", "hive": ""},
+			keys:    []string{"console", "hive", "discovery"},
+			wantErr: "missing images: hive, discovery",
+		},
+		{
+			name:           "Digests required",
+			images:         map[string]string{"console": digestRef, "hive": "quay.io/stolostron/hive:latest"},
+			keys:           []string{"console", "hive"},
+			requireDigests: true,
+			wantErr:        "images not pinned by digest: hive",
+		},
+		{
+			name:           "Missing and undigested images",
+			images:         map[string]string{"hive": "quay.io/stolostron/hive@sha256:abc"},
+			keys:           []string{"console", "hive"},
+			requireDigests: true,
+			wantErr:        "missing images: console; images not pinned by digest: hive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImages(tt.images, tt.keys, tt.requireDigests)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ValidateImages() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ValidateImages() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUniqueImages(t *testing.T) {
+	list := map[string][]string{
+		"a": {"quay.io/b:1", "quay.io/a:1"},
+		"b": {"quay.io/a:1"},
+	}
+	want := []string{"quay.io/a:1", "quay.io/b:1"}
+	if got := UniqueImages(list); !reflect.DeepEqual(got, want) {
+		t.Errorf("UniqueImages() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package images
+
+import (
+	"sort"
+
+	"github.com/stolostron/backplane-operator/pkg/utils"
+)
+
+// UniqueImages returns the sorted image references across all components
+func UniqueImages(list map[string][]string) []string {
+	refs := []string{}
+	for _, componentRefs := range list {
+		for _, ref := range componentRefs {
+			if !utils.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
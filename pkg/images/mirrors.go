@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package images
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Mirror lists the locations mirroring an image source, in order of preference
+type Mirror struct {
+	Source  string
+	Mirrors []string
+}
+
+// GetMirrors reads the digest mirrors configured in ImageDigestMirrorSets and
+// ImageContentSourcePolicies. Resources not served by the cluster are skipped.
+func GetMirrors(ctx context.Context, kubeclient client.Client) ([]Mirror, error) {
+	mirrors := []Mirror{}
+
+	idmsList := &configv1.ImageDigestMirrorSetList{}
+	err := kubeclient.List(ctx, idmsList)
+	if err != nil && !apimeta.IsNoMatchError(err) {
+		return nil, err
+	}
+	for _, idms := range idmsList.Items {
+		for _, m := range idms.Spec.ImageDigestMirrors {
+			mirror := Mirror{Source: m.Source}
+			for _, location := range m.Mirrors {
+				mirror.Mirrors = append(mirror.Mirrors, string(location))
+			}
+			mirrors = append(mirrors, mirror)
+		}
+	}
+
+	icspList := &operatorv1alpha1.ImageContentSourcePolicyList{}
+	err = kubeclient.List(ctx, icspList)
+	if err != nil && !apimeta.IsNoMatchError(err) {
+		return nil, err
+	}
+	for _, icsp := range icspList.Items {
+		for _, m := range icsp.Spec.RepositoryDigestMirrors {
+			mirrors = append(mirrors, Mirror{Source: m.Source, Mirrors: m.Mirrors})
+		}
+	}
+
+	return mirrors, nil
+}
+
+// ResolveMirrors replaces each image with its location in the first mirror of the most specific
+// matching source. Digest mirrors only apply to images referenced by digest. It returns the
+// resolved images and the sorted keys of images that have no mirror.
+func ResolveMirrors(images map[string]string, mirrors []Mirror) (map[string]string, []string) {
+	resolved := make(map[string]string, len(images))
+	unmirrored := []string{}
+
+	for key, ref := range images {
+		resolved[key] = ref
+		if !strings.Contains(ref, "@") {
+			unmirrored = append(unmirrored, key)
+			continue
+		}
+
+		best, bestLen, bestRest := "", -1, ""
+		for _, m := range mirrors {
+			if len(m.Mirrors) == 0 {
+				continue
+			}
+			matched, rest, ok := matchSource(ref, m.Source)
+			if ok && matched > bestLen {
+				best, bestLen, bestRest = m.Mirrors[0], matched, rest
+			}
+		}
+		if bestLen < 0 {
+			unmirrored = append(unmirrored, key)
+			continue
+		}
+		resolved[key] = best + bestRest
+	}
+
+	sort.Strings(unmirrored)
+	return resolved, unmirrored
+}
+
+// matchSource returns how much of the image reference the source matches and the remainder of the
+// reference after the matched part. Sources are a registry, a repository or a wildcard registry
+// such as *.example.com.
+func matchSource(ref, source string) (int, string, bool) {
+	if strings.HasPrefix(source, "*.") {
+		host := ref
+		if i := strings.Index(ref, "/"); i >= 0 {
+			host = ref[:i]
+		}
+		if !strings.HasSuffix(host, source[1:]) {
+			return 0, "", false
+		}
+		return len(source), ref[len(host):], true
+	}
+
+	if !strings.HasPrefix(ref, source) {
+		return 0, "", false
+	}
+	rest := ref[len(source):]
+	switch {
+	case strings.HasPrefix(rest, "/"), strings.HasPrefix(rest, "@"):
+		return len(source), rest, true
+	case strings.HasPrefix(rest, ":") && strings.Contains(source, "/"):
+		// A tag follows a repository, while a registry may be followed by a port
+		return len(source), rest, true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package images
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const digest = "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestGetMirrors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	configv1.AddToScheme(scheme)
+	operatorv1alpha1.AddToScheme(scheme)
+
+	idms := &configv1.ImageDigestMirrorSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "idms"},
+		Spec: configv1.ImageDigestMirrorSetSpec{
+			ImageDigestMirrors: []configv1.ImageDigestMirrors{
+				{Source: "quay.io/stolostron", Mirrors: []configv1.ImageMirror{"mirror.example.com/stolostron"}},
+			},
+		},
+	}
+	icsp := &operatorv1alpha1.ImageContentSourcePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "icsp"},
+		Spec: operatorv1alpha1.ImageContentSourcePolicySpec{
+			RepositoryDigestMirrors: []operatorv1alpha1.RepositoryDigestMirrors{
+				{Source: "registry.redhat.io/multicluster-engine", Mirrors: []string{"mirror.example.com/mce"}},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(idms, icsp).Build()
+
+	got, err := GetMirrors(context.TODO(), cl)
+	if err != nil {
+		t.Fatalf("GetMirrors() error = %v", err)
+	}
+	want := []Mirror{
+		{Source: "quay.io/stolostron", Mirrors: []string{"mirror.example.com/stolostron"}},
+		{Source: "registry.redhat.io/multicluster-engine", Mirrors: []string{"mirror.example.com/mce"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMirrors() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveMirrors(t *testing.T) {
+	mirrors := []Mirror{
+		{Source: "quay.io", Mirrors: []string{"mirror.example.com/quay"}},
+		{Source: "quay.io/stolostron", Mirrors: []string{"mirror.example.com/stolostron", "backup.example.com/stolostron"}},
+		{Source: "*.redhat.io", Mirrors: []string{"mirror.example.com/redhat"}},
+		{Source: "docker.io/library", Mirrors: []string{}},
+	}
+	tests := []struct {
+		name           string
+		images         map[string]string
+		wantResolved   map[string]string
+		wantUnmirrored []string
+	}{
+		{
+			name:           "Most specific source wins",
+			images:         map[string]string{"console": "quay.io/stolostron/console" + digest},
+			wantResolved:   map[string]string{"console": "mirror.example.com/stolostron/console" + digest},
+			wantUnmirrored: []string{},
+		},
+		{
+			name:           "Registry source",
+			images:         map[string]string{"hive": "quay.io/openshift-hive/hive" + digest},
+			wantResolved:   map[string]string{"hive": "mirror.example.com/quay/openshift-hive/hive" + digest},
+			wantUnmirrored: []string{},
+		},
+		{
+			name:           "Wildcard registry",
+			images:         map[string]string{"mce": "registry.redhat.io/multicluster-engine/mce" + digest},
+			wantResolved:   map[string]string{"mce": "mirror.example.com/redhat/multicluster-engine/mce" + digest},
+			wantUnmirrored: []string{},
+		},
+		{
+			name: "Tagged images and unknown sources are not mirrored",
+			images: map[string]string{
+				"tagged":  "quay.io/stolostron/console:latest",
+				"unknown": "example.com/console" + digest,
+				"empty":   "docker.io/library/busybox" + digest,
+			},
+			wantResolved: map[string]string{
+				"tagged":  "quay.io/stolostron/console:latest",
+				"unknown": "example.com/console" + digest,
+				"empty":   "docker.io/library/busybox" + digest,
+			},
+			wantUnmirrored: []string{"empty", "tagged", "unknown"},
+		},
+		{
+			name:           "Partial repository name does not match",
+			images:         map[string]string{"console": "quay.io.example.com/console" + digest},
+			wantResolved:   map[string]string{"console": "quay.io.example.com/console" + digest},
+			wantUnmirrored: []string{"console"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, unmirrored := ResolveMirrors(tt.images, mirrors)
+			if !reflect.DeepEqual(resolved, tt.wantResolved) {
+				t.Errorf("ResolveMirrors() resolved = %v, want %v", resolved, tt.wantResolved)
+			}
+			if !reflect.DeepEqual(unmirrored, tt.wantUnmirrored) {
+				t.Errorf("ResolveMirrors() unmirrored = %v, want %v", unmirrored, tt.wantUnmirrored)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package manifests
+
+import (
+	"fmt"
+	"sort"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/foundation"
+	"github.com/stolostron/backplane-operator/pkg/hive"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderComponent returns the resources the operator applies for an enabled component deployed
+// from a chart: its chart, the CRDs it brings and the custom resources built for it
+func RenderComponent(mce *backplanev1.MultiClusterEngine, component string, imgs map[string]string) ([]*unstructured.Unstructured, error) {
+	chartDir, ok := toggle.ComponentCharts[component]
+	if !ok {
+		return nil, fmt.Errorf("component %s is not deployed from a chart", component)
+	}
+
+	objs := []*unstructured.Unstructured{}
+	if component == backplanev1.ManagedServiceAccount {
+		crds, errs := renderer.RenderCRDs(toggle.ManagedServiceAccountCRDPath)
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		objs = append(objs, crds...)
+	}
+
+	namespace := mce.Spec.TargetNamespace
+	if component == backplanev1.AssistedService && mce.Spec.Overrides != nil && mce.Spec.Overrides.InfrastructureCustomNamespace != "" {
+		namespace = mce.Spec.Overrides.InfrastructureCustomNamespace
+	}
+	templates, errs := renderer.RenderChartWithNamespace(chartDir, mce, imgs, namespace)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to render %s: %w", component, errs[0])
+	}
+	objs = append(objs, templates...)
+
+	switch component {
+	case backplanev1.ClusterManager:
+		objs = append(objs, foundation.ClusterManager(mce, imgs))
+	case backplanev1.Hive:
+		objs = append(objs, hive.HiveConfig(mce))
+	}
+	return objs, nil
+}
+
+// ComponentSummary describes what a component deploys for a MultiClusterEngine, independent of the
+// images it is given
+type ComponentSummary struct {
+	// ImageKeys are the sorted keys of the images the component deploys
+	ImageKeys []string
+	// Namespaces are the sorted namespaces the component deploys namespaced resources into
+	Namespaces []string
+}
+
+// SummarizeComponent renders a component with a placeholder for every image its chart and custom
+// resources could use, and returns the keys whose placeholder is rendered and the namespaces the
+// resources are rendered into. Components not deployed from a chart deploy nothing.
+func SummarizeComponent(mce *backplanev1.MultiClusterEngine, component string) (ComponentSummary, error) {
+	chartDir, ok := toggle.ComponentCharts[component]
+	if !ok {
+		return ComponentSummary{ImageKeys: []string{}, Namespaces: []string{}}, nil
+	}
+	keys, err := renderer.ChartImageKeys(chartDir)
+	if err != nil {
+		return ComponentSummary{}, err
+	}
+	placeholders := renderer.ImagePlaceholders(append(append([]string{}, keys...), foundation.ImageKeys()...))
+	objs, err := RenderComponent(mce, component, placeholders)
+	if err != nil {
+		return ComponentSummary{}, err
+	}
+	imageKeys, err := renderer.RenderedImageKeys(objs, placeholders)
+	if err != nil {
+		return ComponentSummary{}, err
+	}
+
+	namespaces := []string{}
+	for _, o := range objs {
+		if ns := o.GetNamespace(); ns != "" && !utils.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	return ComponentSummary{ImageKeys: imageKeys, Namespaces: namespaces}, nil
+}
+
+// RequiredImageKeys returns the sorted keys of the images a component deploys for the
+// MultiClusterEngine. Components not deployed from a chart require no images.
+func RequiredImageKeys(mce *backplanev1.MultiClusterEngine, component string) ([]string, error) {
+	summary, err := SummarizeComponent(mce, component)
+	if err != nil {
+		return nil, err
+	}
+	return summary.ImageKeys, nil
+}
+
+// ComponentImages returns the sorted image references each summarized component deploys
+func ComponentImages(summaries map[string]ComponentSummary, images map[string]string) map[string][]string {
+	list := map[string][]string{}
+	for component, summary := range summaries {
+		refs := []string{}
+		for _, k := range summary.ImageKeys {
+			if ref := images[k]; ref != "" && !utils.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+		sort.Strings(refs)
+		list[component] = refs
+	}
+	return list
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package manifests
+
+import (
+	"reflect"
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequiredImageKeys(t *testing.T) {
+	setTestEnv(t)
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.14.0")
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: backplanev1.DefaultTargetNamespace},
+	}
+
+	tests := []struct {
+		component string
+		want      []string
+	}{
+		{
+			// The ClusterManager resource deploys the registration, work, placement and addon-manager images
+			component: backplanev1.ClusterManager,
+			want:      []string{"addon_manager", "placement", "registration", "registration_operator", "work"},
+		},
+		{
+			component: backplanev1.Discovery,
+			want:      []string{"discovery_operator"},
+		},
+		{
+			component: backplanev1.LocalCluster,
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			got, err := RequiredImageKeys(mce, tt.component)
+			if err != nil {
+				t.Fatalf("RequiredImageKeys() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RequiredImageKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeComponentNamespaces(t *testing.T) {
+	setTestEnv(t)
+	t.Setenv("ACM_HUB_OCP_VERSION", "4.14.0")
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			TargetNamespace: backplanev1.DefaultTargetNamespace,
+			Overrides:       &backplanev1.Overrides{InfrastructureCustomNamespace: "infra"},
+		},
+	}
+
+	got, err := SummarizeComponent(mce, backplanev1.AssistedService)
+	if err != nil {
+		t.Fatalf("SummarizeComponent() error = %v", err)
+	}
+	if want := []string{"infra"}; !reflect.DeepEqual(got.Namespaces, want) {
+		t.Errorf("SummarizeComponent() namespaces = %v, want %v", got.Namespaces, want)
+	}
+}
+
+func TestComponentImages(t *testing.T) {
+	summaries := map[string]ComponentSummary{
+		backplanev1.ClusterManager: {ImageKeys: []string{"registration", "registration_operator", "work"}},
+		backplanev1.Discovery:      {ImageKeys: []string{"discovery_operator"}},
+	}
+	images := map[string]string{
+		"registration":          "quay.io/stolostron/registration:latest",
+		"registration_operator": "quay.io/stolostron/registration-operator:latest",
+		"work":                  "quay.io/stolostron/registration:latest",
+	}
+
+	want := map[string][]string{
+		backplanev1.ClusterManager: {"quay.io/stolostron/registration-operator:latest", "quay.io/stolostron/registration:latest"},
+		backplanev1.Discovery:      {},
+	}
+	if got := ComponentImages(summaries, images); !reflect.DeepEqual(got, want) {
+		t.Errorf("ComponentImages() = %v, want %v", got, want)
+	}
+}
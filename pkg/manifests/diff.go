@@ -0,0 +1,230 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+// FieldManager is the field manager the operator applies resources with
+const FieldManager = "backplane-operator"
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+var conflictManager = regexp.MustCompile(`conflict with "([^"]+)"`)
+
+// ObjectDiff is the difference between a desired resource and the live resource on the cluster
+type ObjectDiff struct {
+	// Object is the desired resource
+	Object *unstructured.Unstructured
+	// Missing is true if the resource does not exist on the cluster
+	Missing bool
+	// Diff is a line diff of the live resource against the result of applying the desired resource.
+	// It is empty if applying the desired resource changes nothing.
+	Diff string
+	// Conflicts are the fields set by the desired resource that are owned by other field managers
+	Conflicts []Conflict
+}
+
+// Conflict is a field owned by another field manager that the operator would take ownership of
+type Conflict struct {
+	Field   string
+	Manager string
+}
+
+// Drifted returns true if the live resource differs from the desired resource
+func (d ObjectDiff) Drifted() bool {
+	return d.Missing || d.Diff != "" || len(d.Conflicts) > 0
+}
+
+// Diff compares each desired resource against the live resource with a server-side apply dry run,
+// using the operator's field manager. Owner references are set to owner as the operator sets them,
+// unless owner is nil.
+func Diff(ctx context.Context, c client.Client, owner *backplanev1.MultiClusterEngine, objs []*unstructured.Unstructured) ([]ObjectDiff, error) {
+	diffs := []ObjectDiff{}
+	for _, obj := range objs {
+		d, err := diffObject(ctx, c, owner, obj.DeepCopy())
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s %s: %w", obj.GetKind(), namespacedName(obj), err)
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+func diffObject(ctx context.Context, c client.Client, owner *backplanev1.MultiClusterEngine, obj *unstructured.Unstructured) (ObjectDiff, error) {
+	d := ObjectDiff{Object: obj}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		d.Missing = true
+		return d, nil
+	}
+	if err != nil {
+		return d, err
+	}
+
+	if err := prepare(c, owner, obj, live); err != nil {
+		return d, err
+	}
+
+	applied := obj.DeepCopy()
+	err = c.Patch(ctx, applied, client.Apply, client.DryRunAll, client.FieldOwner(FieldManager))
+	if apierrors.IsConflict(err) {
+		d.Conflicts = conflicts(err)
+		applied = obj.DeepCopy()
+		err = c.Patch(ctx, applied, client.Apply, client.DryRunAll, client.FieldOwner(FieldManager), client.ForceOwnership)
+	}
+	if err != nil {
+		return d, err
+	}
+
+	before, err := normalize(live)
+	if err != nil {
+		return d, err
+	}
+	after, err := normalize(applied)
+	if err != nil {
+		return d, err
+	}
+	d.Diff = lineDiff(before, after)
+	return d, nil
+}
+
+// prepare sets the fields the operator adds to rendered resources when it applies them
+func prepare(c client.Client, owner *backplanev1.MultiClusterEngine, obj, live *unstructured.Unstructured) error {
+	// CRDs are applied without an owner and the hypershift-addon ManagedClusterAddOn is never owned
+	if owner != nil && obj.GetKind() != "CustomResourceDefinition" &&
+		!(obj.GetName() == "hypershift-addon" && obj.GetKind() == "ManagedClusterAddOn") {
+		if err := controllerutil.SetControllerReference(owner, obj, c.Scheme()); err != nil {
+			return err
+		}
+	}
+
+	// The config hash is computed from cluster state, so the live value is kept
+	hash, found, err := unstructured.NestedString(live.Object, "spec", "template", "metadata", "annotations", utils.AnnotationConfigHash)
+	if err != nil || !found || obj.GetKind() != "Deployment" {
+		return err
+	}
+	return unstructured.SetNestedField(obj.Object, hash, "spec", "template", "metadata", "annotations", utils.AnnotationConfigHash)
+}
+
+// conflicts returns the fields and managers of a server-side apply conflict error
+func conflicts(err error) []Conflict {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+	found := []Conflict{}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		c := Conflict{Field: cause.Field}
+		if m := conflictManager.FindStringSubmatch(cause.Message); m != nil {
+			c.Manager = m[1]
+		}
+		found = append(found, c)
+	}
+	return found
+}
+
+// normalize returns the resource as YAML without the fields the server maintains
+func normalize(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+	data, err := yaml.Marshal(obj.Object)
+	return string(data), err
+}
+
+// lineDiff returns the lines removed from a and added in b, prefixed with - and +, with unchanged lines
+// around each change for context. It returns an empty string if a and b are equal.
+func lineDiff(a, b string) string {
+	if a == b {
+		return ""
+	}
+	x, y := strings.Split(strings.TrimSuffix(a, "\n"), "\n"), strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] > lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := []string{}
+	for i, j := 0, 0; i < len(x) || j < len(y); {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, "  "+x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+x[i])
+			i++
+		default:
+			lines = append(lines, "+ "+y[j])
+			j++
+		}
+	}
+
+	// Keep changed lines and the context around them
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, "  ") {
+			continue
+		}
+		for k := i - diffContext; k <= i+diffContext; k++ {
+			if k >= 0 && k < len(lines) {
+				keep[k] = true
+			}
+		}
+	}
+	out := []string{}
+	for i, line := range lines {
+		if !keep[i] {
+			continue
+		}
+		if i > 0 && !keep[i-1] && len(out) > 0 {
+			out = append(out, "  ...")
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+func namespacedName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package manifests
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "Equal",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "",
+		},
+		{
+			name: "Changed line",
+			a:    "a\nb\nc\n",
+			b:    "a\nx\nc\n",
+			want: "  a\n- b\n+ x\n  c\n",
+		},
+		{
+			name: "Unchanged lines outside the context are elided",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			b:    "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			want: "+ 0\n  1\n  2\n  3\n  ...\n  8\n  9\n  10\n- 11\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineDiff(tt.a, tt.b); got != tt.want {
+				t.Errorf("lineDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	err := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Field: ".spec.replicas", Message: `conflict with "kubectl-edit" using apps/v1`},
+		{Type: metav1.CauseTypeFieldValueInvalid, Field: ".spec"},
+	}, "Apply failed with 1 conflict")
+
+	want := []Conflict{{Field: ".spec.replicas", Manager: "kubectl-edit"}}
+	if got := conflicts(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("conflicts() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffMissing(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	obj.SetName("config")
+	obj.SetNamespace("multicluster-engine")
+
+	diffs, err := Diff(context.TODO(), cl, nil, []*unstructured.Unstructured{obj})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(diffs) != 1 || !diffs[0].Missing || !diffs[0].Drifted() {
+		t.Errorf("Diff() = %v, want the configmap to be missing", diffs)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package manifests renders the full set of resources the operator deploys for a MultiClusterEngine
+// without a cluster, using the same rendering code as the controller.
+package manifests
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/images"
+	"github.com/stolostron/backplane-operator/pkg/platform"
+	renderer "github.com/stolostron/backplane-operator/pkg/rendering"
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const managedByACMLabel = "multiclusterhubs.operator.open-cluster-management.io/managed-by"
+
+// Facts describe the cluster the manifests are rendered for, in place of the values the operator
+// reads from the cluster
+type Facts struct {
+	// Platform is the platform name, OpenShift or Kubernetes
+	Platform string
+	// OCPVersion is the OpenShift version of the cluster
+	OCPVersion string
+	// IngressDomain is the cluster ingress domain
+	IngressDomain string
+	// HubType is one of mce, acm, stolostron-engine or stolostron
+	HubType string
+}
+
+// Render returns every resource the operator applies for the MultiClusterEngine: CRDs, charts that
+// are always deployed, the charts of enabled components and the custom resources they require. The
+// operator defaults are applied to a copy of mce first. Cluster facts are passed to the charts through
+// the process environment, as they are in the operator. imgs are the images to deploy, with the
+// overrides and mirrors of the MultiClusterEngine already applied.
+func Render(mce *backplanev1.MultiClusterEngine, imgs map[string]string, facts Facts) ([]*unstructured.Unstructured, error) {
+	mce = mce.DeepCopy()
+	if err := facts.apply(mce); err != nil {
+		return nil, err
+	}
+	if _, err := utils.SetDefaults(mce, facts.Platform, facts.OCPVersion); err != nil {
+		return nil, err
+	}
+	objs, errs := renderer.RenderCRDs(renderer.CRDsDir)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	always, errs := renderer.RenderCharts(renderer.AlwaysChartsDir, mce, imgs)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	objs = append(objs, always...)
+
+	for _, component := range EnabledComponents(mce, facts.Platform) {
+		keys, err := RequiredImageKeys(mce, component)
+		if err != nil {
+			return nil, err
+		}
+		if err := images.ValidateImages(imgs, keys, utils.ShouldRequireImageDigests(mce)); err != nil {
+			return nil, fmt.Errorf("component %s: %w", component, err)
+		}
+
+		componentObjs, err := RenderComponent(mce, component, imgs)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, componentObjs...)
+	}
+
+	Sort(objs)
+	return objs, nil
+}
+
+// EnabledComponents returns the sorted components deployed from a chart that are enabled. The console
+// is only deployed on OpenShift.
+func EnabledComponents(mce *backplanev1.MultiClusterEngine, platformName string) []string {
+	components := []string{}
+	for component := range toggle.ComponentCharts {
+		if !mce.Enabled(component) {
+			continue
+		}
+		if component == backplanev1.ConsoleMCE && platformName != platform.OpenShift {
+			continue
+		}
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	return components
+}
+
+// Sort orders resources so they can be applied in sequence: CRDs, then namespaces, then everything
+// else by kind, namespace and name
+func Sort(objs []*unstructured.Unstructured) {
+	rank := func(u *unstructured.Unstructured) int {
+		switch u.GetKind() {
+		case "CustomResourceDefinition":
+			return 0
+		case "Namespace":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if a.GetKind() != b.GetKind() {
+			return a.GetKind() < b.GetKind()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+}
+
+// apply sets the environment and labels the renderer reads cluster facts from
+func (f Facts) apply(mce *backplanev1.MultiClusterEngine) error {
+	if f.Platform != platform.OpenShift && f.Platform != platform.Kubernetes {
+		return fmt.Errorf("unknown platform %q", f.Platform)
+	}
+	os.Setenv("ACM_HUB_PLATFORM", f.Platform)
+	os.Setenv("ACM_HUB_OCP_VERSION", f.OCPVersion)
+	os.Setenv("ACM_CLUSTER_INGRESS_DOMAIN", f.IngressDomain)
+
+	labels := mce.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	switch utils.HubType(f.HubType) {
+	case utils.HubTypeMCE, utils.HubTypeACM:
+		os.Setenv("OPERATOR_PACKAGE", "multicluster-engine")
+	case utils.HubTypeStolostronEngine, utils.HubTypeStolostron:
+		os.Setenv("OPERATOR_PACKAGE", "stolostron-engine")
+	default:
+		return fmt.Errorf("unknown hub type %q", f.HubType)
+	}
+	switch utils.HubType(f.HubType) {
+	case utils.HubTypeACM, utils.HubTypeStolostron:
+		labels[managedByACMLabel] = "true"
+	default:
+		delete(labels, managedByACMLabel)
+	}
+	mce.SetLabels(labels)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package manifests
+
+import (
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/platform"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testImages() map[string]string {
+	images := map[string]string{}
+	for _, k := range utils.GetTestImages() {
+		images[k] = "quay.io/stolostron/" + k + ":test"
+	}
+	return images
+}
+
+// setTestEnv registers the environment Render modifies so it is restored after the test
+func setTestEnv(t *testing.T) {
+	t.Setenv("DIRECTORY_OVERRIDE", "../../")
+	for _, key := range []string{"ACM_HUB_PLATFORM", "ACM_HUB_OCP_VERSION", "ACM_CLUSTER_INGRESS_DOMAIN", "OPERATOR_PACKAGE"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestRender(t *testing.T) {
+	setTestEnv(t)
+	mce := &backplanev1.MultiClusterEngine{ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"}}
+
+	tests := []struct {
+		name       string
+		facts      Facts
+		wantRoutes bool
+		wantErr    bool
+	}{
+		{
+			name:       "OpenShift",
+			facts:      Facts{Platform: platform.OpenShift, OCPVersion: "4.14.0", HubType: "mce"},
+			wantRoutes: true,
+		},
+		{
+			name:  "Kubernetes",
+			facts: Facts{Platform: platform.Kubernetes, HubType: "mce"},
+		},
+		{
+			name:    "Unknown hub type",
+			facts:   Facts{Platform: platform.Kubernetes, HubType: "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid OCP version",
+			facts:   Facts{Platform: platform.OpenShift, OCPVersion: "latest", HubType: "mce"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := Render(mce, testImages(), tt.facts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if mce.Spec.TargetNamespace != "" {
+				t.Error("Render() should not modify the MultiClusterEngine")
+			}
+			if objs[0].GetKind() != "CustomResourceDefinition" {
+				t.Errorf("expected CRDs first, got %s", objs[0].GetKind())
+			}
+			kinds := map[string]int{}
+			for _, o := range objs {
+				kinds[o.GetKind()]++
+			}
+			if kinds["ClusterManager"] != 1 || kinds["HiveConfig"] != 1 {
+				t.Errorf("expected the ClusterManager and HiveConfig resources, got %v", kinds)
+			}
+			if (kinds["Route"] > 0) != tt.wantRoutes {
+				t.Errorf("got %d Routes, want routes %v", kinds["Route"], tt.wantRoutes)
+			}
+			if (kinds["ConsolePlugin"] > 0) != tt.wantRoutes {
+				t.Errorf("got %d ConsolePlugins on %s", kinds["ConsolePlugin"], tt.facts.Platform)
+			}
+		})
+	}
+}
+
+func TestRenderMissingClusterManagerImages(t *testing.T) {
+	setTestEnv(t)
+	mce := &backplanev1.MultiClusterEngine{ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"}}
+	facts := Facts{Platform: platform.Kubernetes, HubType: "mce"}
+
+	for _, key := range []string{"placement", "addon_manager"} {
+		imgs := testImages()
+		delete(imgs, key)
+		if _, err := Render(mce, imgs, facts); err == nil {
+			t.Errorf("expected Render() to fail without the %s image", key)
+		}
+	}
+}
+
+func TestEnabledComponents(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{
+				Components: []backplanev1.ComponentConfig{
+					{Name: backplanev1.Hive, Enabled: true},
+					{Name: backplanev1.ConsoleMCE, Enabled: true},
+					{Name: backplanev1.Discovery, Enabled: false},
+					{Name: backplanev1.LocalCluster, Enabled: true},
+				},
+			},
+		},
+	}
+	if got := EnabledComponents(mce, platform.OpenShift); len(got) != 2 || got[0] != backplanev1.ConsoleMCE || got[1] != backplanev1.Hive {
+		t.Errorf("EnabledComponents() = %v", got)
+	}
+	if got := EnabledComponents(mce, platform.Kubernetes); len(got) != 1 || got[0] != backplanev1.Hive {
+		t.Errorf("EnabledComponents() = %v", got)
+	}
+}
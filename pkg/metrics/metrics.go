@@ -0,0 +1,164 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package metrics defines the Prometheus metrics the operator exposes about the MultiClusterEngines it
+// manages. They are registered with the controller-runtime registry and served on its metrics endpoint.
+package metrics
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// phases are the phases reported by the phase gauge
+var phases = []backplanev1.PhaseType{
+	backplanev1.MultiClusterEnginePhaseProgressing,
+	backplanev1.MultiClusterEnginePhaseAvailable,
+	backplanev1.MultiClusterEnginePhaseUninstalling,
+	backplanev1.MultiClusterEnginePhaseError,
+	backplanev1.MultiClusterEnginePhaseUnimplemented,
+	backplanev1.MultiClusterEnginePhaseDegraded,
+	backplanev1.MultiClusterEnginePhasePaused,
+	backplanev1.MultiClusterEnginePhaseUpgrading,
+}
+
+var (
+	// Phase is 1 for the current phase of a MultiClusterEngine and 0 for every other phase
+	Phase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mce_phase",
+		Help: "The phase of the MultiClusterEngine. 1 for the current phase, 0 otherwise.",
+	}, []string{"name", "phase"})
+
+	// Info exposes the versions of a MultiClusterEngine as labels
+	Info = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mce_info",
+		Help: "The current and desired versions of the MultiClusterEngine.",
+	}, []string{"name", "current_version", "desired_version"})
+
+	// Upgrading is 1 while the current version of a MultiClusterEngine differs from the desired version
+	Upgrading = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mce_upgrading",
+		Help: "Whether the current version of the MultiClusterEngine differs from the desired version.",
+	}, []string{"name"})
+
+	// ComponentEnabled is 1 for each component enabled in a MultiClusterEngine and 0 for each disabled component
+	ComponentEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mce_component_enabled",
+		Help: "Whether a component is enabled in the MultiClusterEngine spec.",
+	}, []string{"name", "component"})
+
+	// ComponentAvailable is 1 for each resource reported in the status of a MultiClusterEngine that is
+	// available and 0 for each that is not
+	ComponentAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mce_component_available",
+		Help: "Whether a resource reported in the MultiClusterEngine status components is available.",
+	}, []string{"name", "component", "kind"})
+
+	// ComponentReconcileDuration observes the time taken to reconcile each component
+	ComponentReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mce_component_reconcile_duration_seconds",
+		Help:    "Time taken to reconcile a component of the MultiClusterEngine.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"component"})
+
+	// ApplyErrors counts the errors applying resources
+	ApplyErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mce_apply_errors_total",
+		Help: "Number of errors applying resources, by component and kind.",
+	}, []string{"component", "kind"})
+
+	// DeleteErrors counts the errors deleting resources
+	DeleteErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mce_delete_errors_total",
+		Help: "Number of errors deleting resources, by component and kind.",
+	}, []string{"component", "kind"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		Phase,
+		Info,
+		Upgrading,
+		ComponentEnabled,
+		ComponentAvailable,
+		ComponentReconcileDuration,
+		ApplyErrors,
+		DeleteErrors,
+	)
+}
+
+// series identifies a series of one of the gauges by its label values
+type series struct {
+	gauge  *prometheus.GaugeVec
+	values string
+}
+
+var (
+	reportedMu sync.Mutex
+	// reported are the series last set for each MultiClusterEngine, with their label values
+	reported = map[string]map[series][]string{}
+)
+
+// ReportStatus sets the gauges of a MultiClusterEngine from its spec and reported status. The current
+// series are set before the series no longer in the spec or status are deleted, so a scrape never
+// misses a current series.
+func ReportStatus(mce *backplanev1.MultiClusterEngine) {
+	name := mce.Name
+	current := map[series][]string{}
+	set := func(gauge *prometheus.GaugeVec, value float64, labelValues ...string) {
+		gauge.WithLabelValues(labelValues...).Set(value)
+		current[series{gauge: gauge, values: strings.Join(labelValues, "\x00")}] = labelValues
+	}
+
+	for _, phase := range phases {
+		value := 0.0
+		if mce.Status.Phase == phase {
+			value = 1
+		}
+		set(Phase, value, name, string(phase))
+	}
+	set(Info, 1, name, mce.Status.CurrentVersion, mce.Status.DesiredVersion)
+	set(Upgrading, boolValue(mce.Status.DesiredVersion != "" && mce.Status.CurrentVersion != mce.Status.DesiredVersion), name)
+
+	if mce.Spec.Overrides != nil {
+		for _, c := range mce.Spec.Overrides.Components {
+			set(ComponentEnabled, boolValue(c.Enabled), name, c.Name)
+		}
+	}
+	for _, c := range mce.Status.Components {
+		set(ComponentAvailable, boolValue(c.Available), name, c.Name, c.Kind)
+	}
+
+	reportedMu.Lock()
+	defer reportedMu.Unlock()
+	for s, labelValues := range reported[name] {
+		if _, ok := current[s]; !ok {
+			s.gauge.DeleteLabelValues(labelValues...)
+		}
+	}
+	reported[name] = current
+}
+
+// Delete removes the gauges of a MultiClusterEngine
+func Delete(name string) {
+	labels := prometheus.Labels{"name": name}
+	Phase.DeletePartialMatch(labels)
+	Info.DeletePartialMatch(labels)
+	Upgrading.DeletePartialMatch(labels)
+	ComponentEnabled.DeletePartialMatch(labels)
+	ComponentAvailable.DeletePartialMatch(labels)
+
+	reportedMu.Lock()
+	defer reportedMu.Unlock()
+	delete(reported, name)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
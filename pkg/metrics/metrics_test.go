@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReportStatus(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec: backplanev1.MultiClusterEngineSpec{
+			Overrides: &backplanev1.Overrides{Components: []backplanev1.ComponentConfig{
+				{Name: backplanev1.Hive, Enabled: true},
+				{Name: backplanev1.Discovery, Enabled: false},
+			}},
+		},
+		Status: backplanev1.MultiClusterEngineStatus{
+			Phase:          backplanev1.MultiClusterEnginePhaseProgressing,
+			CurrentVersion: "2.3.0",
+			DesiredVersion: "2.4.0",
+			Components: []backplanev1.ComponentCondition{
+				{Name: "hive-operator", Kind: "Deployment", Available: false},
+				{Name: "ocm-controller", Kind: "Deployment", Available: true},
+			},
+		},
+	}
+	ReportStatus(mce)
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Current phase", testutil.ToFloat64(Phase.WithLabelValues(mce.Name, "Progressing")), 1},
+		{"Other phase", testutil.ToFloat64(Phase.WithLabelValues(mce.Name, "Available")), 0},
+		{"Versions", testutil.ToFloat64(Info.WithLabelValues(mce.Name, "2.3.0", "2.4.0")), 1},
+		{"Upgrading", testutil.ToFloat64(Upgrading.WithLabelValues(mce.Name)), 1},
+		{"Enabled component", testutil.ToFloat64(ComponentEnabled.WithLabelValues(mce.Name, backplanev1.Hive)), 1},
+		{"Disabled component", testutil.ToFloat64(ComponentEnabled.WithLabelValues(mce.Name, backplanev1.Discovery)), 0},
+		{"Unavailable component", testutil.ToFloat64(ComponentAvailable.WithLabelValues(mce.Name, "hive-operator", "Deployment")), 0},
+		{"Available component", testutil.ToFloat64(ComponentAvailable.WithLabelValues(mce.Name, "ocm-controller", "Deployment")), 1},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// Components removed from the status are no longer reported
+	mce.Status.Components = mce.Status.Components[1:]
+	ReportStatus(mce)
+	if got := testutil.CollectAndCount(ComponentAvailable); got != 1 {
+		t.Errorf("expected 1 component availability series, got %d", got)
+	}
+
+	// A version change replaces the info series
+	mce.Status.CurrentVersion = "2.4.0"
+	ReportStatus(mce)
+	if got := testutil.CollectAndCount(Info); got != 1 {
+		t.Errorf("expected 1 info series, got %d", got)
+	}
+	if got := testutil.ToFloat64(Info.WithLabelValues(mce.Name, "2.4.0", "2.4.0")); got != 1 {
+		t.Errorf("info for the new version = %v, want 1", got)
+	}
+
+	Delete(mce.Name)
+	if got := testutil.CollectAndCount(Phase); got != 0 {
+		t.Errorf("expected the phase series to be deleted, got %d", got)
+	}
+}
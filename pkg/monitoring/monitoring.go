@@ -0,0 +1,293 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package monitoring builds the Service, ServiceMonitor and PrometheusRule that expose the operator's
+// own metrics to the cluster monitoring stack and alert on the health of a MultiClusterEngine, and
+// serves those metrics over HTTPS.
+package monitoring
+
+import (
+	"fmt"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MetricsServiceName is the Service in front of the operator's metrics endpoint
+	MetricsServiceName = "multicluster-engine-operator-metrics"
+	// MetricsCertSecretName is the secret the OpenShift service-ca operator issues the serving
+	// certificate of the metrics endpoint into
+	MetricsCertSecretName = "multicluster-engine-operator-metrics-cert"
+	// ServiceMonitorName is the ServiceMonitor scraping the operator's metrics
+	ServiceMonitorName = "multicluster-engine-operator"
+	// PrometheusRuleName is the PrometheusRule alerting on the operator's metrics
+	PrometheusRuleName = "multicluster-engine-operator"
+
+	// PrometheusRoleName is the Role and RoleBinding letting the cluster Prometheus scrape the operator
+	PrometheusRoleName = "multicluster-engine-operator-prometheus"
+	// OpenShiftMonitoringNamespace is the namespace of the OpenShift cluster monitoring stack
+	OpenShiftMonitoringNamespace = "openshift-monitoring"
+	// ClusterMonitoringLabel marks the namespaces whose ServiceMonitors and PrometheusRules the OpenShift
+	// cluster monitoring stack picks up
+	ClusterMonitoringLabel = "openshift.io/cluster-monitoring"
+
+	metricsPortName = "https"
+	// servingCertAnnotation asks the OpenShift service-ca operator for a serving certificate of a Service
+	servingCertAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// serviceCAConfigMap is published in every namespace on OpenShift with the CA bundle of the
+	// service-ca operator under serviceCAKey
+	serviceCAConfigMap = "openshift-service-ca.crt"
+	serviceCAKey       = "service-ca.crt"
+	// prometheusServiceAccount is the service account of the OpenShift cluster Prometheus
+	prometheusServiceAccount = "prometheus-k8s"
+)
+
+// Defaults of the alert thresholds when they are not set in the spec
+const (
+	DefaultComponentUnavailableFor        = 10 * time.Minute
+	DefaultReconcileErrorsThreshold int32 = 5
+	DefaultPhaseErrorFor                  = 5 * time.Minute
+	DefaultUpgradeStuckFor                = time.Hour
+)
+
+var (
+	// ServiceMonitorGVK is the kind of the ServiceMonitor
+	ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+	// PrometheusRuleGVK is the kind of the PrometheusRule
+	PrometheusRuleGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+)
+
+// Enabled returns true if the spec asks for the monitoring resources to be deployed
+func Enabled(m *backplanev1.MultiClusterEngine) bool {
+	return m.Spec.Monitoring != nil && m.Spec.Monitoring.Enabled
+}
+
+// Resources returns the Service in the operator namespace selecting the operator pods, and the
+// ServiceMonitor and PrometheusRule in the target namespace of the MultiClusterEngine. On OpenShift the
+// cluster monitoring stack picks these up once the target namespace is labeled with
+// ClusterMonitoringLabel, and a Role and RoleBinding are added for its Prometheus. The cluster
+// Prometheus is only granted access to the namespaces of the platform, so without them it can neither
+// discover the operator pods nor pass the access review of the metrics endpoint.
+//
+// When serviceCA is set, the serving certificate of the metrics endpoint is issued by the OpenShift
+// service-ca operator and the ServiceMonitor verifies it. Otherwise the operator serves a self-signed
+// certificate, which the ServiceMonitor has no CA to verify against.
+func Resources(m *backplanev1.MultiClusterEngine, operatorNamespace string, openShift, serviceCA bool) ([]*unstructured.Unstructured, error) {
+	objs := []runtime.Object{
+		metricsService(operatorNamespace, serviceCA),
+		serviceMonitor(m.Spec.TargetNamespace, operatorNamespace, serviceCA),
+		prometheusRule(m, m.Spec.TargetNamespace),
+	}
+	if openShift {
+		objs = append(objs, prometheusRole(operatorNamespace), prometheusRoleBinding(operatorNamespace))
+	}
+	resources := []*unstructured.Unstructured{}
+	for _, obj := range objs {
+		u, err := utils.CoreToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		utils.AddBackplaneConfigLabels(u, m.GetName())
+		resources = append(resources, u)
+	}
+	return resources, nil
+}
+
+// MetricsServerName is the name the metrics endpoint is served under in the operator namespace
+func MetricsServerName(operatorNamespace string) string {
+	return fmt.Sprintf("%s.%s.svc", MetricsServiceName, operatorNamespace)
+}
+
+func metricsService(namespace string, serviceCA bool) *corev1.Service {
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MetricsServiceName,
+			Namespace: namespace,
+			Labels:    map[string]string{"control-plane": "backplane-operator"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"control-plane": "backplane-operator"},
+			Ports: []corev1.ServicePort{{
+				Name:       metricsPortName,
+				Port:       SecureMetricsPort,
+				TargetPort: intstr.FromInt(SecureMetricsPort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+	if serviceCA {
+		service.Annotations = map[string]string{servingCertAnnotation: MetricsCertSecretName}
+	}
+	return service
+}
+
+func serviceMonitor(namespace, operatorNamespace string, serviceCA bool) *monitoringv1.ServiceMonitor {
+	tlsConfig := monitoringv1.SafeTLSConfig{InsecureSkipVerify: true}
+	if serviceCA {
+		tlsConfig = monitoringv1.SafeTLSConfig{
+			CA: monitoringv1.SecretOrConfigMap{
+				ConfigMap: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: serviceCAConfigMap},
+					Key:                  serviceCAKey,
+				},
+			},
+			ServerName: MetricsServerName(operatorNamespace),
+		}
+	}
+	return &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{APIVersion: ServiceMonitorGVK.GroupVersion().String(), Kind: ServiceMonitorGVK.Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ServiceMonitorName,
+			Namespace: namespace,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"control-plane": "backplane-operator"},
+			},
+			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{operatorNamespace}},
+			Endpoints: []monitoringv1.Endpoint{{
+				Port:            metricsPortName,
+				Path:            "/metrics",
+				Scheme:          "https",
+				BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+				TLSConfig:       &monitoringv1.TLSConfig{SafeTLSConfig: tlsConfig},
+			}},
+		},
+	}
+}
+
+// prometheusRole lets the cluster Prometheus discover the operator pods and, through the metrics
+// server's access review of the metrics Service, scrape them
+func prometheusRole(namespace string) *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PrometheusRoleName,
+			Namespace: namespace,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"services", "endpoints", "pods"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+	}
+}
+
+func prometheusRoleBinding(namespace string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PrometheusRoleName,
+			Namespace: namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     PrometheusRoleName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      prometheusServiceAccount,
+			Namespace: OpenShiftMonitoringNamespace,
+		}},
+	}
+}
+
+func prometheusRule(m *backplanev1.MultiClusterEngine, namespace string) *monitoringv1.PrometheusRule {
+	cfg := m.Spec.Monitoring
+	if cfg == nil {
+		cfg = &backplanev1.Monitoring{}
+	}
+	componentUnavailableFor := durationOrDefault(cfg.ComponentUnavailableFor, DefaultComponentUnavailableFor)
+	phaseErrorFor := durationOrDefault(cfg.PhaseErrorFor, DefaultPhaseErrorFor)
+	upgradeStuckFor := durationOrDefault(cfg.UpgradeStuckFor, DefaultUpgradeStuckFor)
+	errorsThreshold := DefaultReconcileErrorsThreshold
+	if cfg.ReconcileErrorsThreshold != nil {
+		errorsThreshold = *cfg.ReconcileErrorsThreshold
+	}
+
+	return &monitoringv1.PrometheusRule{
+		TypeMeta: metav1.TypeMeta{APIVersion: PrometheusRuleGVK.GroupVersion().String(), Kind: PrometheusRuleGVK.Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PrometheusRuleName,
+			Namespace: namespace,
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{{
+				Name: "multicluster-engine",
+				Rules: []monitoringv1.Rule{
+					{
+						Alert: "MultiClusterEngineComponentUnavailable",
+						Expr:  intstr.FromString(`mce_component_available == 0`),
+						For:   promDuration(componentUnavailableFor),
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+						Annotations: map[string]string{
+							"summary":     "A MultiClusterEngine component is unavailable",
+							"description": "{{ $labels.kind }} {{ $labels.component }} of MultiClusterEngine {{ $labels.name }} has been unavailable for more than " + string(promDuration(componentUnavailableFor)) + ".",
+						},
+					},
+					{
+						Alert: "MultiClusterEngineReconcileErrors",
+						Expr: intstr.FromString(fmt.Sprintf(
+							`sum by (component) (increase({__name__=~"mce_apply_errors_total|mce_delete_errors_total"}[15m])) > %d`, errorsThreshold)),
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+						Annotations: map[string]string{
+							"summary":     "The MultiClusterEngine operator is failing to reconcile a component",
+							"description": fmt.Sprintf("There were more than %d errors applying or deleting the resources of component {{ $labels.component }} in the last 15 minutes.", errorsThreshold),
+						},
+					},
+					{
+						Alert: "MultiClusterEnginePhaseError",
+						Expr:  intstr.FromString(`mce_phase{phase="Error"} == 1`),
+						For:   promDuration(phaseErrorFor),
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+						Annotations: map[string]string{
+							"summary":     "The MultiClusterEngine is in the Error phase",
+							"description": "MultiClusterEngine {{ $labels.name }} has been in the Error phase for more than " + string(promDuration(phaseErrorFor)) + ".",
+						},
+					},
+					{
+						Alert: "MultiClusterEngineUpgradeStuck",
+						Expr:  intstr.FromString(`mce_upgrading == 1`),
+						For:   promDuration(upgradeStuckFor),
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+						Annotations: map[string]string{
+							"summary":     "The MultiClusterEngine upgrade is not completing",
+							"description": "MultiClusterEngine {{ $labels.name }} has been upgrading for more than " + string(promDuration(upgradeStuckFor)) + ".",
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func durationOrDefault(d *metav1.Duration, def time.Duration) time.Duration {
+	if d == nil {
+		return def
+	}
+	return d.Duration
+}
+
+// promDuration formats a duration in seconds, which Prometheus accepts regardless of its magnitude
+func promDuration(d time.Duration) monitoringv1.Duration {
+	return monitoringv1.Duration(fmt.Sprintf("%ds", int64(d.Seconds())))
+}
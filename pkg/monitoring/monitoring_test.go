@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestResources(t *testing.T) {
+	threshold := int32(2)
+	tests := []struct {
+		name       string
+		monitoring *backplanev1.Monitoring
+		wantFor    map[string]string
+		wantExpr   string
+	}{
+		{
+			name:       "defaults",
+			monitoring: &backplanev1.Monitoring{Enabled: true},
+			wantFor: map[string]string{
+				"MultiClusterEngineComponentUnavailable": "600s",
+				"MultiClusterEnginePhaseError":           "300s",
+				"MultiClusterEngineUpgradeStuck":         "3600s",
+			},
+			wantExpr: `sum by (component) (increase({__name__=~"mce_apply_errors_total|mce_delete_errors_total"}[15m])) > 5`,
+		},
+		{
+			name: "overrides",
+			monitoring: &backplanev1.Monitoring{
+				Enabled:                  true,
+				ComponentUnavailableFor:  &metav1.Duration{Duration: time.Minute},
+				ReconcileErrorsThreshold: &threshold,
+				PhaseErrorFor:            &metav1.Duration{Duration: 90 * time.Second},
+				UpgradeStuckFor:          &metav1.Duration{Duration: 2 * time.Hour},
+			},
+			wantFor: map[string]string{
+				"MultiClusterEngineComponentUnavailable": "60s",
+				"MultiClusterEnginePhaseError":           "90s",
+				"MultiClusterEngineUpgradeStuck":         "7200s",
+			},
+			wantExpr: `sum by (component) (increase({__name__=~"mce_apply_errors_total|mce_delete_errors_total"}[15m])) > 2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mce := &backplanev1.MultiClusterEngine{
+				ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+				Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "mce", Monitoring: tt.monitoring},
+			}
+			resources, err := Resources(mce, "operator", false, false)
+			if err != nil {
+				t.Fatalf("Resources() error = %v", err)
+			}
+			if len(resources) != 3 {
+				t.Fatalf("expected 3 resources, got %d", len(resources))
+			}
+			for _, r := range resources {
+				if r.GetLabels()["backplaneconfig.name"] != "multiclusterengine" {
+					t.Errorf("expected %s %s to be labeled with the MultiClusterEngine", r.GetKind(), r.GetName())
+				}
+			}
+
+			service, monitor, rule := resources[0], resources[1], resources[2]
+			if service.GetKind() != "Service" || service.GetNamespace() != "operator" {
+				t.Errorf("expected the Service in the operator namespace, got %s %s/%s", service.GetKind(), service.GetNamespace(), service.GetName())
+			}
+			if monitor.GroupVersionKind() != ServiceMonitorGVK || monitor.GetNamespace() != "mce" {
+				t.Errorf("expected the ServiceMonitor in the target namespace, got %s %s/%s", monitor.GetKind(), monitor.GetNamespace(), monitor.GetName())
+			}
+			names, _, _ := unstructured.NestedStringSlice(monitor.Object, "spec", "namespaceSelector", "matchNames")
+			if len(names) != 1 || names[0] != "operator" {
+				t.Errorf("expected the ServiceMonitor to select the operator namespace, got %v", names)
+			}
+			endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+			if skip, _, _ := unstructured.NestedBool(endpoints[0].(map[string]interface{}), "tlsConfig", "insecureSkipVerify"); !skip {
+				t.Error("expected the ServiceMonitor to skip verification of the self-signed certificate")
+			}
+			if rule.GroupVersionKind() != PrometheusRuleGVK || rule.GetNamespace() != "mce" {
+				t.Errorf("expected the PrometheusRule in the target namespace, got %s %s/%s", rule.GetKind(), rule.GetNamespace(), rule.GetName())
+			}
+
+			groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+			rules, _, _ := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+			if len(rules) != 4 {
+				t.Fatalf("expected 4 alerts, got %d", len(rules))
+			}
+			for _, r := range rules {
+				r := r.(map[string]interface{})
+				alert := r["alert"].(string)
+				if want, ok := tt.wantFor[alert]; ok && r["for"] != want {
+					t.Errorf("%s for = %v, want %s", alert, r["for"], want)
+				}
+				if alert == "MultiClusterEngineReconcileErrors" && r["expr"] != tt.wantExpr {
+					t.Errorf("%s expr = %v, want %s", alert, r["expr"], tt.wantExpr)
+				}
+			}
+		})
+	}
+}
+
+func TestResourcesOpenShift(t *testing.T) {
+	mce := &backplanev1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiclusterengine"},
+		Spec:       backplanev1.MultiClusterEngineSpec{TargetNamespace: "mce", Monitoring: &backplanev1.Monitoring{Enabled: true}},
+	}
+	resources, err := Resources(mce, "operator", true, true)
+	if err != nil {
+		t.Fatalf("Resources() error = %v", err)
+	}
+	if len(resources) != 5 {
+		t.Fatalf("expected 5 resources, got %d", len(resources))
+	}
+
+	service, monitor, rule, role, binding := resources[0], resources[1], resources[2], resources[3], resources[4]
+	ports, _, _ := unstructured.NestedSlice(service.Object, "spec", "ports")
+	if port := ports[0].(map[string]interface{}); port["name"] != "https" || port["port"] != int64(SecureMetricsPort) {
+		t.Errorf("expected the Service to only expose the secure metrics port, got %v", ports)
+	}
+	if monitor.GetNamespace() != "mce" || rule.GetNamespace() != "mce" {
+		t.Errorf("expected the ServiceMonitor and PrometheusRule in the target namespace, got %s and %s", monitor.GetNamespace(), rule.GetNamespace())
+	}
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+	endpoint := endpoints[0].(map[string]interface{})
+	if endpoint["scheme"] != "https" || endpoint["bearerTokenFile"] == nil {
+		t.Errorf("expected the ServiceMonitor to scrape over HTTPS with a bearer token, got %v", endpoint)
+	}
+	if service.GetAnnotations()[servingCertAnnotation] != MetricsCertSecretName {
+		t.Errorf("expected the Service to request a serving certificate from the service-ca operator, got %v", service.GetAnnotations())
+	}
+	tlsConfig := endpoint["tlsConfig"].(map[string]interface{})
+	caName, _, _ := unstructured.NestedString(tlsConfig, "ca", "configMap", "name")
+	if caName != "openshift-service-ca.crt" || tlsConfig["serverName"] != "multicluster-engine-operator-metrics.operator.svc" || tlsConfig["insecureSkipVerify"] != nil {
+		t.Errorf("expected the ServiceMonitor to verify the service-ca certificate, got %v", tlsConfig)
+	}
+	if role.GetKind() != "Role" || role.GetNamespace() != "operator" {
+		t.Errorf("expected a Role in the operator namespace, got %s %s/%s", role.GetKind(), role.GetNamespace(), role.GetName())
+	}
+	subjects, _, _ := unstructured.NestedSlice(binding.Object, "subjects")
+	subject := subjects[0].(map[string]interface{})
+	if binding.GetKind() != "RoleBinding" || subject["name"] != "prometheus-k8s" || subject["namespace"] != OpenShiftMonitoringNamespace {
+		t.Errorf("expected a RoleBinding for the cluster Prometheus, got %s with subjects %v", binding.GetKind(), subjects)
+	}
+}
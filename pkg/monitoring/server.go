@@ -0,0 +1,197 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package monitoring
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stolostron/backplane-operator/pkg/certs"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecureMetricsPort is the port the operator serves its metrics on over HTTPS
+	SecureMetricsPort = 8443
+
+	certValidity = 365 * 24 * time.Hour
+	// serviceCARecheck is how often the secret issued by the service-ca operator is read again, to pick
+	// up its rotations or its creation after the server started
+	serviceCARecheck = 5 * time.Minute
+)
+
+// MetricsServer serves the operator's metrics over HTTPS. Each request must carry a bearer token of a
+// user allowed to get the metrics Service in the operator namespace, which is checked with a
+// TokenReview and a SubjectAccessReview. When serviceCA is set the serving certificate is read from
+// the secret issued by the OpenShift service-ca operator, so scrapers can verify it. Otherwise, or
+// while that secret is not available, the certificate is self-signed.
+type MetricsServer struct {
+	addr      string
+	namespace string
+	client    client.Client
+	gatherer  prometheus.Gatherer
+	serviceCA bool
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	rotateAt time.Time
+}
+
+// NewMetricsServer returns a server for the metrics of gatherer on addr. c is used to review the
+// tokens of requests and read the serving certificate, and must not be cached.
+func NewMetricsServer(addr, operatorNamespace string, c client.Client, gatherer prometheus.Gatherer, serviceCA bool) *MetricsServer {
+	return &MetricsServer{addr: addr, namespace: operatorNamespace, client: c, gatherer: gatherer, serviceCA: serviceCA}
+}
+
+// Start serves metrics until ctx is done
+func (s *MetricsServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.authorize(promhttp.HandlerFor(s.gatherer, promhttp.HandlerOpts{})))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return s.certificate(hello.Context(), time.Now())
+			},
+		},
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.ServeTLS(listener, "", ""); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, so every replica serves its own metrics
+func (s *MetricsServer) NeedLeaderElection() bool {
+	return false
+}
+
+// authorize only passes requests from users allowed to get the metrics Service
+func (s *MetricsServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if token == "" || token == req.Header.Get("Authorization") {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+		if err := s.client.Create(req.Context(), review); err != nil {
+			ctrl.Log.WithName("metrics").Error(err, "failed to review token")
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if !review.Status.Authenticated {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		user := review.Status.User
+		extra := map[string]authorizationv1.ExtraValue{}
+		for k, v := range user.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+		access := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   user.Username,
+				UID:    user.UID,
+				Groups: user.Groups,
+				Extra:  extra,
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: s.namespace,
+					Verb:      "get",
+					Resource:  "services",
+					Name:      MetricsServiceName,
+				},
+			},
+		}
+		if err := s.client.Create(req.Context(), access); err != nil {
+			ctrl.Log.WithName("metrics").Error(err, "failed to review access")
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if !access.Status.Allowed {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// certificate returns the serving certificate, replacing it when it is due for rotation. With
+// serviceCA set it is read again from the issued secret every serviceCARecheck.
+func (s *MetricsServer) certificate(ctx context.Context, now time.Time) (*tls.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cert != nil && now.Before(s.rotateAt) {
+		return s.cert, nil
+	}
+
+	var serving certs.KeyPair
+	if s.serviceCA {
+		secret := &corev1.Secret{}
+		err := s.client.Get(ctx, types.NamespacedName{Name: MetricsCertSecretName, Namespace: s.namespace}, secret)
+		if err == nil {
+			serving = certs.KeyPair{Cert: secret.Data[corev1.TLSCertKey], Key: secret.Data[corev1.TLSPrivateKeyKey]}
+		} else {
+			ctrl.Log.WithName("metrics").Info("Serving a self-signed certificate, as the service-ca certificate is not available",
+				"secret", MetricsCertSecretName, "error", err.Error())
+		}
+	}
+	if serving.Cert == nil {
+		var err error
+		if serving, err = selfSignedCert(s.namespace, now); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := tls.X509KeyPair(serving.Cert, serving.Key)
+	if err != nil {
+		return nil, err
+	}
+	rotateAt, err := certs.RotationTime(serving.Cert)
+	if err != nil {
+		return nil, err
+	}
+	if s.serviceCA && rotateAt.After(now.Add(serviceCARecheck)) {
+		rotateAt = now.Add(serviceCARecheck)
+	}
+	s.cert, s.rotateAt = &cert, rotateAt
+	return s.cert, nil
+}
+
+// selfSignedCert returns a serving certificate for the metrics Service signed by a new CA
+func selfSignedCert(namespace string, now time.Time) (certs.KeyPair, error) {
+	ca, err := certs.NewCA("multicluster-engine-operator-metrics", now, certValidity)
+	if err != nil {
+		return certs.KeyPair{}, err
+	}
+	dnsNames := []string{
+		MetricsServerName(namespace),
+		MetricsServerName(namespace) + ".cluster.local",
+	}
+	return certs.NewServingCert(ca, dnsNames, now, certValidity)
+}
@@ -0,0 +1,131 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stolostron/backplane-operator/pkg/certs"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestMetricsServerAuthorize(t *testing.T) {
+	scheme := runtime.NewScheme()
+	authenticationv1.AddToScheme(scheme)
+	authorizationv1.AddToScheme(scheme)
+
+	// The API server authenticates the prometheus and other tokens, and only allows prometheus
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == "prometheus" || review.Spec.Token == "other" {
+					review.Status.Authenticated = true
+					review.Status.User.Username = review.Spec.Token
+				}
+			case *authorizationv1.SubjectAccessReview:
+				attrs := review.Spec.ResourceAttributes
+				review.Status.Allowed = review.Spec.User == "prometheus" && attrs.Namespace == "operator" &&
+					attrs.Verb == "get" && attrs.Resource == "services" && attrs.Name == MetricsServiceName
+			}
+			return nil
+		},
+	}).Build()
+
+	s := NewMetricsServer(":0", "operator", cl, prometheus.NewRegistry(), false)
+	handler := s.authorize(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for header, want := range map[string]int{
+		"":                  http.StatusUnauthorized,
+		"Basic prometheus":  http.StatusUnauthorized,
+		"Bearer unknown":    http.StatusUnauthorized,
+		"Bearer other":      http.StatusForbidden,
+		"Bearer prometheus": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Authorization %q: status = %d, want %d", header, rec.Code, want)
+		}
+	}
+}
+
+func TestMetricsServerCertificate(t *testing.T) {
+	s := NewMetricsServer(":0", "operator", nil, prometheus.NewRegistry(), false)
+	now := time.Now()
+	cert, err := s.certificate(context.TODO(), now)
+	if err != nil {
+		t.Fatalf("certificate() error = %v", err)
+	}
+	if again, _ := s.certificate(context.TODO(), now.Add(time.Hour)); again != cert {
+		t.Error("expected the certificate to be reused before rotation")
+	}
+	if rotated, _ := s.certificate(context.TODO(), now.Add(certValidity)); rotated == cert {
+		t.Error("expected the certificate to be replaced when due for rotation")
+	}
+}
+
+func TestMetricsServerServiceCACertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewMetricsServer(":0", "operator", cl, prometheus.NewRegistry(), true)
+	now := time.Now()
+
+	// The secret is not issued yet
+	selfSigned, err := s.certificate(context.TODO(), now)
+	if err != nil {
+		t.Fatalf("certificate() error = %v", err)
+	}
+	if !s.rotateAt.Equal(now.Add(serviceCARecheck)) {
+		t.Errorf("expected the issued secret to be read again after %s, got %s", serviceCARecheck, s.rotateAt.Sub(now))
+	}
+
+	ca, err := certs.NewCA("service-ca", now, certValidity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serving, err := certs.NewServingCert(ca, []string{MetricsServerName("operator")}, now, certValidity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: MetricsCertSecretName, Namespace: "operator"},
+		Data:       map[string][]byte{corev1.TLSCertKey: serving.Cert, corev1.TLSPrivateKeyKey: serving.Key},
+	}
+	if err := cl.Create(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if again, _ := s.certificate(context.TODO(), now.Add(time.Minute)); again != selfSigned {
+		t.Error("expected the certificate to be reused until the issued secret is read again")
+	}
+	issued, err := s.certificate(context.TODO(), now.Add(serviceCARecheck))
+	if err != nil {
+		t.Fatalf("certificate() error = %v", err)
+	}
+	want, _ := tls.X509KeyPair(serving.Cert, serving.Key)
+	if !bytes.Equal(issued.Certificate[0], want.Certificate[0]) {
+		t.Error("expected the certificate issued by the service-ca operator to be served")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package platform
+
+import (
+	"context"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IngressDomainEnvVar optionally sets the ingress domain on platforms without an Ingress config API
+const IngressDomainEnvVar = "CLUSTER_INGRESS_DOMAIN"
+
+// KubernetesPlatform describes a generic Kubernetes cluster such as kind, which has no OpenShift
+// config APIs or console
+type KubernetesPlatform struct {
+	version   string
+	serviceCA bool
+}
+
+// NewKubernetesPlatform returns a generic Kubernetes platform running the given server version
+func NewKubernetesPlatform(version string) *KubernetesPlatform {
+	return &KubernetesPlatform{version: version}
+}
+
+func (p *KubernetesPlatform) Name() string {
+	return Kubernetes
+}
+
+// Version returns the Kubernetes server version found during detection
+func (p *KubernetesPlatform) Version(ctx context.Context, c client.Client) (string, error) {
+	return p.version, nil
+}
+
+// IngressDomain returns the domain set in the environment, if any
+func (p *KubernetesPlatform) IngressDomain(ctx context.Context, c client.Client) (string, error) {
+	return os.Getenv(IngressDomainEnvVar), nil
+}
+
+func (p *KubernetesPlatform) ConsoleAvailable(ctx context.Context, c client.Client) (bool, error) {
+	return false, nil
+}
+
+func (p *KubernetesPlatform) ServiceCAAvailable() bool {
+	return p.serviceCA
+}
@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	semver "github.com/Masterminds/semver"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OpenShiftPlatform reads cluster information from the OpenShift config APIs
+type OpenShiftPlatform struct {
+	serviceCA bool
+}
+
+// NewOpenShiftPlatform returns the OpenShift platform
+func NewOpenShiftPlatform(serviceCA bool) *OpenShiftPlatform {
+	return &OpenShiftPlatform{serviceCA: serviceCA}
+}
+
+func (p *OpenShiftPlatform) Name() string {
+	return OpenShift
+}
+
+// Version returns the most recent version in the ClusterVersion history
+func (p *OpenShiftPlatform) Version(ctx context.Context, c client.Client) (string, error) {
+	clusterVersion := &configv1.ClusterVersion{}
+	err := c.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if len(clusterVersion.Status.History) == 0 {
+		return "", fmt.Errorf("failed to detect status in clusterversion.status.history")
+	}
+	return clusterVersion.Status.History[0].Version, nil
+}
+
+// IngressDomain returns the domain of the cluster Ingress config
+func (p *OpenShiftPlatform) IngressDomain(ctx context.Context, c client.Client) (string, error) {
+	clusterIngress := &configv1.Ingress{}
+	err := c.Get(ctx, types.NamespacedName{Name: "cluster"}, clusterIngress)
+	if err != nil {
+		return "", err
+	}
+
+	if clusterIngress.Spec.Domain == "" {
+		return "", fmt.Errorf("Domain not found or empty in Ingress")
+	}
+	return clusterIngress.Spec.Domain, nil
+}
+
+// ConsoleAvailable checks if the OCP Console is enabled. Before OCP v4.12 the console cannot be
+// disabled, so it is always available. Otherwise the Console capability must be enabled. The version
+// stored in ACM_HUB_OCP_VERSION takes precedence over the ClusterVersion history.
+func (p *OpenShiftPlatform) ConsoleAvailable(ctx context.Context, c client.Client) (bool, error) {
+	versionStatus := &configv1.ClusterVersion{}
+	err := c.Get(ctx, types.NamespacedName{Name: "version"}, versionStatus)
+	if err != nil {
+		return false, err
+	}
+	ocpVersion, ok := os.LookupEnv("ACM_HUB_OCP_VERSION")
+	if !ok {
+		ocpVersion, err = p.Version(ctx, c)
+		if err != nil {
+			return false, err
+		}
+	}
+	semverVersion, err := semver.NewVersion(ocpVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert ocp version to semver compatible value: %w", err)
+	}
+	// -0 allows for prerelease builds to pass the validation.
+	// If -0 is removed, developer/rc builds will not pass this check
+	//OCP Console can only be disabled in OCP 4.12+
+	constraint, err := semver.NewConstraint(">= 4.12.0-0")
+	if err != nil {
+		return false, fmt.Errorf("failed to set ocp version constraint: %w", err)
+	}
+	if !constraint.Check(semverVersion) {
+		return true, nil
+	}
+	for _, v := range versionStatus.Status.Capabilities.EnabledCapabilities {
+		if v == "Console" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *OpenShiftPlatform) ServiceCAAvailable() bool {
+	return p.serviceCA
+}
@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package platform
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stolostron/backplane-operator/pkg/certs"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OpenShift is the name of the OpenShift Container Platform
+	OpenShift = "OpenShift"
+	// Kubernetes is the name of any Kubernetes distribution that is not OpenShift
+	Kubernetes = "Kubernetes"
+)
+
+// Platform provides information about the cluster the operator is running on, so that the reconciler
+// does not need to assume OpenShift APIs are present
+type Platform interface {
+	// Name identifies the platform. It is passed to charts as hubconfig.platform.
+	Name() string
+	// Version returns the version of the cluster
+	Version(ctx context.Context, c client.Client) (string, error)
+	// IngressDomain returns the base domain used to expose routes, which may be empty
+	IngressDomain(ctx context.Context, c client.Client) (string, error)
+	// ConsoleAvailable returns true if the cluster has a console that can load the MCE console plugin
+	ConsoleAvailable(ctx context.Context, c client.Client) (bool, error)
+	// ServiceCAAvailable returns true if the cluster can issue serving certificates for services
+	ServiceCAAvailable() bool
+}
+
+// Detect determines the platform using API discovery. A cluster serving the OpenShift
+// ClusterVersion API is OpenShift; any other cluster is treated as generic Kubernetes.
+func Detect(dc discovery.DiscoveryInterface) (Platform, error) {
+	serviceCA, err := certs.ServiceCAAvailable(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	openshift, err := hasResource(dc, "config.openshift.io/v1", "clusterversions")
+	if err != nil {
+		return nil, err
+	}
+	if openshift {
+		return &OpenShiftPlatform{serviceCA: serviceCA}, nil
+	}
+
+	info, err := dc.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	return &KubernetesPlatform{version: strings.TrimPrefix(info.GitVersion, "v"), serviceCA: serviceCA}, nil
+}
+
+func hasResource(dc discovery.DiscoveryInterface, groupVersion, resource string) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		if discovery.IsGroupDiscoveryFailedError(err) || apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
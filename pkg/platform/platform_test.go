@@ -0,0 +1,138 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_Detect(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		want      string
+		wantCA    bool
+	}{
+		{
+			name: "OpenShift with service-ca",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "config.openshift.io/v1",
+					APIResources: []metav1.APIResource{{Name: "clusterversions"}},
+				},
+				{
+					GroupVersion: "operator.openshift.io/v1",
+					APIResources: []metav1.APIResource{{Name: "servicecas"}},
+				},
+			},
+			want:   OpenShift,
+			wantCA: true,
+		},
+		{
+			name:      "Kubernetes",
+			resources: []*metav1.APIResourceList{},
+			want:      Kubernetes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := &fakediscovery.FakeDiscovery{
+				Fake:               &clienttesting.Fake{Resources: tt.resources},
+				FakedServerVersion: &version.Info{GitVersion: "v1.27.3"},
+			}
+			got, err := Detect(dc)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if got.Name() != tt.want {
+				t.Errorf("Detect() = %s, want %s", got.Name(), tt.want)
+			}
+			if got.ServiceCAAvailable() != tt.wantCA {
+				t.Errorf("Detect() ServiceCAAvailable = %v, want %v", got.ServiceCAAvailable(), tt.wantCA)
+			}
+		})
+	}
+}
+
+func Test_KubernetesPlatform(t *testing.T) {
+	t.Setenv(IngressDomainEnvVar, "apps.kind.local")
+	p := NewKubernetesPlatform("1.27.3")
+	c := fake.NewClientBuilder().Build()
+
+	if v, err := p.Version(context.TODO(), c); err != nil || v != "1.27.3" {
+		t.Errorf("Version() = %s, %v, want 1.27.3", v, err)
+	}
+	if d, err := p.IngressDomain(context.TODO(), c); err != nil || d != "apps.kind.local" {
+		t.Errorf("IngressDomain() = %s, %v, want apps.kind.local", d, err)
+	}
+	if ok, err := p.ConsoleAvailable(context.TODO(), c); err != nil || ok {
+		t.Errorf("ConsoleAvailable() = %v, %v, want false", ok, err)
+	}
+}
+
+func Test_OpenShiftPlatform(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := configv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		capabilities []configv1.ClusterVersionCapability
+		version      string
+		wantConsole  bool
+	}{
+		{
+			name:        "console always available before 4.12",
+			version:     "4.11.0",
+			wantConsole: true,
+		},
+		{
+			name:         "console capability enabled",
+			version:      "4.12.0",
+			capabilities: []configv1.ClusterVersionCapability{"Console"},
+			wantConsole:  true,
+		},
+		{
+			name:        "console capability disabled",
+			version:     "4.12.0",
+			wantConsole: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := &configv1.ClusterVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "version"},
+				Status: configv1.ClusterVersionStatus{
+					History:      []configv1.UpdateHistory{{Version: tt.version}},
+					Capabilities: configv1.ClusterVersionCapabilitiesStatus{EnabledCapabilities: tt.capabilities},
+				},
+			}
+			ingress := &configv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       configv1.IngressSpec{Domain: "apps.example.com"},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cv, ingress).Build()
+			p := NewOpenShiftPlatform(true)
+
+			if v, err := p.Version(context.TODO(), c); err != nil || v != tt.version {
+				t.Errorf("Version() = %s, %v, want %s", v, err, tt.version)
+			}
+			if d, err := p.IngressDomain(context.TODO(), c); err != nil || d != "apps.example.com" {
+				t.Errorf("IngressDomain() = %s, %v, want apps.example.com", d, err)
+			}
+			if ok, err := p.ConsoleAvailable(context.TODO(), c); err != nil || ok != tt.wantConsole {
+				t.Errorf("ConsoleAvailable() = %v, %v, want %v", ok, err, tt.wantConsole)
+			}
+		})
+	}
+}
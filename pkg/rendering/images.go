@@ -0,0 +1,83 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	loader "helm.sh/helm/v3/pkg/chart/loader"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	imageKeyPattern = regexp.MustCompile(`\.Values\.global\.imageOverrides\.([A-Za-z0-9_]+)`)
+
+	// chartImageKeys caches the image keys of each chart, since charts do not change at runtime
+	chartImageKeys sync.Map
+)
+
+// ChartImageKeys returns the sorted image keys referenced by the templates of a chart, including
+// those in templates or blocks that are not rendered for every MultiClusterEngine
+func ChartImageKeys(chartPath string) ([]string, error) {
+	if val, ok := os.LookupEnv("DIRECTORY_OVERRIDE"); ok {
+		chartPath = path.Join(val, chartPath)
+	}
+	if keys, ok := chartImageKeys.Load(chartPath); ok {
+		return keys.([]string), nil
+	}
+
+	chart, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]bool{}
+	for _, t := range chart.Templates {
+		for _, match := range imageKeyPattern.FindAllSubmatch(t.Data, -1) {
+			found[string(match[1])] = true
+		}
+	}
+	keys := make([]string, 0, len(found))
+	for k := range found {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	chartImageKeys.Store(chartPath, keys)
+	return keys, nil
+}
+
+// ImagePlaceholders returns a distinct image for each key, to render resources with so the keys
+// they use can be found with RenderedImageKeys
+func ImagePlaceholders(keys []string) map[string]string {
+	placeholders := map[string]string{}
+	for _, k := range keys {
+		placeholders[k] = "image-key.invalid/" + k + ":placeholder"
+	}
+	return placeholders
+}
+
+// RenderedImageKeys returns the sorted keys whose placeholder image appears in the rendered resources
+func RenderedImageKeys(objs []*unstructured.Unstructured, placeholders map[string]string) ([]string, error) {
+	rendered := strings.Builder{}
+	for _, o := range objs {
+		data, err := json.Marshal(o.Object)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Write(data)
+	}
+	keys := []string{}
+	for k, placeholder := range placeholders {
+		if strings.Contains(rendered.String(), placeholder) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package renderer
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stolostron/backplane-operator/pkg/toggle"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestChartImageKeys(t *testing.T) {
+	os.Setenv("DIRECTORY_OVERRIDE", "../../")
+	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+
+	keys, err := ChartImageKeys(toggle.DiscoveryChartDir)
+	if err != nil {
+		t.Fatalf("ChartImageKeys() error = %v", err)
+	}
+	if want := []string{"discovery_operator"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ChartImageKeys() = %v, want %v", keys, want)
+	}
+
+	// Every image a component's chart references must be among the test images, or the component cannot be deployed in tests
+	for component, chartDir := range toggle.ComponentCharts {
+		keys, err := ChartImageKeys(chartDir)
+		if err != nil {
+			t.Fatalf("ChartImageKeys(%s) error = %v", chartDir, err)
+		}
+		for _, k := range keys {
+			if !utils.Contains(utils.GetTestImages(), k) {
+				t.Errorf("component %s requires image %s, which is not a test image", component, k)
+			}
+		}
+	}
+}
+
+func TestRenderedImageKeys(t *testing.T) {
+	placeholders := ImagePlaceholders([]string{"registration", "registration_operator", "work"})
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"image": placeholders["registration_operator"]},
+	}}
+
+	keys, err := RenderedImageKeys([]*unstructured.Unstructured{obj}, placeholders)
+	if err != nil {
+		t.Fatalf("RenderedImageKeys() error = %v", err)
+	}
+	if want := []string{"registration_operator"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("RenderedImageKeys() = %v, want %v", keys, want)
+	}
+}
@@ -12,11 +12,13 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 
 	loader "helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 
 	v1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/platform"
 	"github.com/stolostron/backplane-operator/pkg/utils"
 	"helm.sh/helm/v3/pkg/engine"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +29,7 @@ import (
 
 const (
 	AlwaysChartsDir = "pkg/templates/charts/always"
+	CRDsDir         = "pkg/templates/crds"
 )
 
 type Values struct {
@@ -39,6 +42,7 @@ type Global struct {
 	ImageOverrides map[string]string `json:"imageOverrides" structs:"imageOverrides"`
 	PullPolicy     string            `json:"pullPolicy" structs:"pullPolicy"`
 	PullSecret     string            `json:"pullSecret" structs:"pullSecret"`
+	PullSecrets    []string          `json:"pullSecrets" structs:"pullSecrets"`
 	Namespace      string            `json:"namespace" structs:"namespace"`
 	ConfigSecret   string            `json:"configSecret" structs:"configSecret"`
 }
@@ -51,6 +55,7 @@ type HubConfig struct {
 	OCPVersion           string            `json:"ocpVersion" structs:"ocpVersion"`
 	ClusterIngressDomain string            `json:"clusterIngressDomain" structs:"clusterIngressDomain"`
 	HubType              string            `json:"hubType" structs:"hubType"`
+	Platform             string            `json:"platform" structs:"platform"`
 }
 
 type Toleration struct {
@@ -182,6 +187,32 @@ func RenderCRDs(crdDir string) ([]*unstructured.Unstructured, []error) {
 	return crds, errs
 }
 
+// crdComponents maps each directory of CRDsDir to the component whose custom resources its CRDs define
+var crdComponents = map[string]string{
+	"assisted-service":    v1.AssistedService,
+	"cluster-lifecycle":   v1.ClusterLifecycle,
+	"cluster-manager":     v1.ClusterManager,
+	"cluster-proxy-addon": v1.ClusterProxyAddon,
+	"discovery-operator":  v1.Discovery,
+	"foundation":          v1.ServerFoundation,
+	"hive-operator":       v1.Hive,
+}
+
+// RenderComponentCRDs returns the name of each CRD under crdDir mapped to the component it belongs to.
+// CRDs in directories that don't belong to a component are left out.
+func RenderComponentCRDs(crdDir string) (map[string]string, []error) {
+	components := map[string]string{}
+	errs := []error{}
+	for dir, component := range crdComponents {
+		crds, dirErrs := RenderCRDs(path.Join(crdDir, dir))
+		errs = append(errs, dirErrs...)
+		for _, crd := range crds {
+			components[crd.GetName()] = component
+		}
+	}
+	return components, errs
+}
+
 func RenderCharts(chartDir string, backplaneConfig *v1.MultiClusterEngine, images map[string]string) ([]*unstructured.Unstructured, []error) {
 	log := log.FromContext(context.Background())
 	var templates []*unstructured.Unstructured
@@ -262,6 +293,10 @@ func renderTemplates(chartPath string, backplaneConfig *v1.MultiClusterEngine, i
 	}
 
 	for fileName, templateFile := range rawTemplates {
+		// Templates may render nothing, such as Routes on platforms without them
+		if strings.TrimSpace(templateFile) == "" {
+			continue
+		}
 		unstructured := &unstructured.Unstructured{}
 		if err = yaml.Unmarshal([]byte(templateFile), unstructured); err != nil {
 			return nil, append(errs, fmt.Errorf("error converting file %s to unstructured", fileName))
@@ -280,6 +315,19 @@ func renderTemplates(chartPath string, backplaneConfig *v1.MultiClusterEngine, i
 	return templates, errs
 }
 
+// Environment returns the environment variables charts are rendered with, so callers caching what
+// a chart renders can tell when it changes
+func Environment() map[string]string {
+	env := map[string]string{}
+	for _, name := range []string{"DIRECTORY_OVERRIDE", "ACM_HUB_OCP_VERSION", "ACM_CLUSTER_INGRESS_DOMAIN", "ACM_HUB_PLATFORM"} {
+		env[name] = os.Getenv(name)
+	}
+	for k, v := range utils.ProxyConfigs() {
+		env[k] = v
+	}
+	return env
+}
+
 func injectValuesOverrides(values *Values, backplaneConfig *v1.MultiClusterEngine, images map[string]string) {
 
 	values.Global.ImageOverrides = images
@@ -288,7 +336,10 @@ func injectValuesOverrides(values *Values, backplaneConfig *v1.MultiClusterEngin
 
 	values.Global.Namespace = backplaneConfig.Spec.TargetNamespace
 
-	values.Global.PullSecret = backplaneConfig.Spec.ImagePullSecret
+	values.Global.PullSecrets = backplaneConfig.PullSecrets()
+	if len(values.Global.PullSecrets) > 0 {
+		values.Global.PullSecret = values.Global.PullSecrets[0]
+	}
 
 	if v1.IsInHostedMode(backplaneConfig) {
 		secretNN, err := utils.GetHostedCredentialsSecret(backplaneConfig)
@@ -314,11 +365,12 @@ func injectValuesOverrides(values *Values, backplaneConfig *v1.MultiClusterEngin
 
 	values.HubConfig.HubType = utils.GetHubType(backplaneConfig)
 
-	if utils.ProxyEnvVarsAreSet() {
-		proxyVar := map[string]string{}
-		proxyVar["HTTP_PROXY"] = os.Getenv("HTTP_PROXY")
-		proxyVar["HTTPS_PROXY"] = os.Getenv("HTTPS_PROXY")
-		proxyVar["NO_PROXY"] = os.Getenv("NO_PROXY")
+	values.HubConfig.Platform = platform.OpenShift
+	if hubPlatform := os.Getenv("ACM_HUB_PLATFORM"); hubPlatform != "" {
+		values.HubConfig.Platform = hubPlatform
+	}
+
+	if proxyVar := utils.ProxyConfigs(); proxyVar != nil {
 		values.HubConfig.ProxyConfigs = proxyVar
 	}
 }
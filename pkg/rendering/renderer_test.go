@@ -302,3 +302,79 @@ func TestRenderCRDs(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderComponentCRDs(t *testing.T) {
+	os.Setenv("DIRECTORY_OVERRIDE", "../../")
+	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+
+	got, errs := RenderComponentCRDs(crdsDir)
+	if len(errs) > 0 {
+		t.Fatalf("RenderComponentCRDs() errs = %v", errs)
+	}
+
+	all, _ := RenderCRDs(crdsDir)
+	if len(got) != len(all) {
+		t.Errorf("RenderComponentCRDs() mapped %d CRDs, want all %d CRDs to belong to a component", len(got), len(all))
+	}
+	if got["hiveconfigs.hive.openshift.io"] != backplane.Hive {
+		t.Errorf("RenderComponentCRDs() hiveconfigs component = %q, want %q", got["hiveconfigs.hive.openshift.io"], backplane.Hive)
+	}
+}
+
+func TestRenderChartPlatform(t *testing.T) {
+	os.Setenv("DIRECTORY_OVERRIDE", "../../")
+	defer os.Unsetenv("DIRECTORY_OVERRIDE")
+	os.Setenv("POD_NAMESPACE", "default")
+	defer os.Unsetenv("POD_NAMESPACE")
+
+	testImages := map[string]string{}
+	for _, v := range utils.GetTestImages() {
+		testImages[v] = "quay.io/test/test:Test"
+	}
+	mce := &backplane.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "testBackplane"},
+		Spec:       backplane.MultiClusterEngineSpec{TargetNamespace: "default"},
+	}
+
+	tests := []struct {
+		name       string
+		platform   string
+		ocpVersion string
+		wantRoutes bool
+	}{
+		{
+			name:       "OpenShift renders routes",
+			platform:   "OpenShift",
+			ocpVersion: "4.12.0",
+			wantRoutes: true,
+		},
+		{
+			name:       "Kubernetes renders no routes",
+			platform:   "Kubernetes",
+			ocpVersion: "1.27.3",
+			wantRoutes: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("ACM_HUB_PLATFORM", tt.platform)
+			defer os.Unsetenv("ACM_HUB_PLATFORM")
+			os.Setenv("ACM_HUB_OCP_VERSION", tt.ocpVersion)
+			defer os.Unsetenv("ACM_HUB_OCP_VERSION")
+
+			templates, errs := RenderChart("pkg/templates/charts/toggle/cluster-proxy-addon", mce, testImages)
+			if len(errs) > 0 {
+				t.Fatalf("RenderChart() errs = %v", errs)
+			}
+			gotRoutes := false
+			for _, template := range templates {
+				if template.GetKind() == "Route" {
+					gotRoutes = true
+				}
+			}
+			if gotRoutes != tt.wantRoutes {
+				t.Errorf("RenderChart() rendered routes = %v, want %v", gotRoutes, tt.wantRoutes)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var apiServiceGVK = schema.GroupVersionKind{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"}
+
+// APIServiceStatus fulfills the StatusReporter interface for aggregated APIServices
+type APIServiceStatus struct {
+	types.NamespacedName
+}
+
+func (s APIServiceStatus) GetName() string {
+	return s.Name
+}
+
+func (s APIServiceStatus) GetNamespace() string {
+	return ""
+}
+
+func (s APIServiceStatus) GetKind() string {
+	return apiServiceGVK.Kind
+}
+
+// Converts the Available condition of an APIService to a backplane component status
+func (s APIServiceStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	u := &unstructured.Unstructured{}
+	if cc := getResource(k8sClient, types.NamespacedName{Name: s.Name}, apiServiceGVK, u); cc != nil {
+		return *cc
+	}
+	return mapAPIService(u)
+}
+
+func mapAPIService(u *unstructured.Unstructured) bpv1.ComponentCondition {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Available" {
+			continue
+		}
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+		if cond["status"] == "True" {
+			return availableStatus(u.GetName(), apiServiceGVK.Kind, true, reason, "")
+		}
+		return availableStatus(u.GetName(), apiServiceGVK.Kind, false, reason, message)
+	}
+	return availableStatus(u.GetName(), apiServiceGVK.Kind, false, "NoAvailableCondition",
+		fmt.Sprintf("APIService %s has not reported an Available condition", u.GetName()))
+}
@@ -0,0 +1,32 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAPIServiceStatus(t *testing.T) {
+	apiService := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiregistration.k8s.io/v1",
+		"kind":       "APIService",
+		"metadata":   map[string]interface{}{"name": "v1.clusterview.open-cluster-management.io"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False", "reason": "MissingEndpoints", "message": "endpoints for service/clusterview in \"mce\" have no addresses"},
+			},
+		},
+	}}
+	got := mapAPIService(apiService)
+	if got.Available || got.Reason != "MissingEndpoints" {
+		t.Errorf("expected an unavailable APIService, got %v", got)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(apiService.Object, "status", "conditions")
+	conditions[0].(map[string]interface{})["status"] = "True"
+	unstructured.SetNestedSlice(apiService.Object, conditions, "status", "conditions")
+	if got := mapAPIService(apiService); !got.Available {
+		t.Errorf("expected an available APIService, got %v", got)
+	}
+}
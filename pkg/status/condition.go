@@ -35,6 +35,30 @@ const (
 	UnsupportedConfigReason = "UnsupportedConfiguration"
 	// ComponentDisabledReason means the component has been specifically disabled by user in config
 	ComponentDisabledReason = "ComponentDisabled"
+	// CRDsEstablishedReason is when all CRDs managed by the operator are established
+	CRDsEstablishedReason = "CRDsEstablished"
+	// CRDsNotEstablishedReason is when one or more CRDs managed by the operator are missing or not established
+	CRDsNotEstablishedReason = "CRDsNotEstablished"
+	// CRDsUpdateBlockedReason is when a CRD update was refused because it would remove a version objects are stored in
+	CRDsUpdateBlockedReason = "CRDsUpdateBlocked"
+	// AllImagesMirroredReason is when every image was resolved to a cluster mirror
+	AllImagesMirroredReason = "AllImagesMirrored"
+	// ImagesNotMirroredReason is when one or more images have no cluster mirror
+	ImagesNotMirroredReason = "ImagesNotMirrored"
+	// ImageOverridesAppliedReason is when every entry of the image override configmap was applied
+	ImageOverridesAppliedReason = "ImageOverridesApplied"
+	// ImageOverridesInvalidReason is when image override entries were skipped or match no known image
+	ImageOverridesInvalidReason = "ImageOverridesInvalid"
+	// InvalidImagesReason is when a component's images are missing or violate the image policy
+	InvalidImagesReason = "InvalidImages"
+	// ComponentsDegradedReason is when components that were available at the current version become unavailable
+	ComponentsDegradedReason = "ComponentsDegraded"
+	// UpgradeInProgressReason is when components are rolling out a new version over an installed one
+	UpgradeInProgressReason = "UpgradeInProgress"
+	// ReadinessDeadlineExceededReason is when a component has been unavailable for longer than its readiness deadline
+	ReadinessDeadlineExceededReason = "ReadinessDeadlineExceeded"
+	// AsExpectedReason is when a Degraded, Paused, Upgrading or Failure condition is false
+	AsExpectedReason = "AsExpected"
 )
 
 // NewCondition creates a new condition.
@@ -0,0 +1,144 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/crds"
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const crdStatusName = "custom-resource-definitions"
+
+// NewCRDStatus returns a StatusReporter that checks the health of the desired CRDs
+func NewCRDStatus(crds []*unstructured.Unstructured) StatusReporter {
+	return CRDStatus{
+		NamespacedName: types.NamespacedName{Name: crdStatusName},
+		crds:           crds,
+	}
+}
+
+// CRDStatus fulfills the StatusReporter interface for the CustomResourceDefinitions managed by the operator.
+// It ensures every CRD exists, has been established by the API server and can be safely updated.
+type CRDStatus struct {
+	types.NamespacedName
+	// Desired state of the CRDs to check
+	crds []*unstructured.Unstructured
+}
+
+func (s CRDStatus) GetName() string {
+	return s.Name
+}
+
+func (s CRDStatus) GetNamespace() string {
+	return ""
+}
+
+func (s CRDStatus) GetKind() string {
+	return "CustomResourceDefinition"
+}
+
+// Converts the state of the managed CRDs to a backplane component status
+func (s CRDStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	missing := ""
+	notEstablished := ""
+	blocked := ""
+	migrating := ""
+	for _, desired := range s.crds {
+		name := desired.GetName()
+		crd := &apixv1.CustomResourceDefinition{}
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: name}, crd)
+		if apierrors.IsNotFound(err) {
+			missing = fmt.Sprintf("%s <%s>", missing, name)
+			continue
+		}
+		if err != nil {
+			return unknownStatus(s.GetName(), s.GetKind())
+		}
+		if utils.AnnotationPresent(utils.AnnotationMCEIgnore, crd) {
+			continue
+		}
+		if established, reason := crdEstablished(crd); !established {
+			notEstablished = fmt.Sprintf("%s <%s: %s>", notEstablished, name, reason)
+		}
+		if compat, err := crds.CheckCompatibility(crd, desired); err == nil && !compat.Safe() {
+			blocked = fmt.Sprintf("%s <%s: %s>", blocked, name, strings.Join(compat.Warnings(), ", "))
+		}
+		if crds.NeedsMigration(crd) {
+			migrating = fmt.Sprintf("%s <%s>", migrating, name)
+		}
+	}
+
+	if missing == "" && notEstablished == "" && blocked == "" {
+		message := ""
+		if migrating != "" {
+			message = fmt.Sprintf("The following CRDs are migrating to a new storage version:%s.", migrating)
+		}
+		return bpv1.ComponentCondition{
+			Name:      s.GetName(),
+			Kind:      s.GetKind(),
+			Type:      "Established",
+			Status:    metav1.ConditionTrue,
+			Reason:    CRDsEstablishedReason,
+			Message:   message,
+			Available: true,
+		}
+	}
+
+	if missing == "" && notEstablished == "" {
+		return bpv1.ComponentCondition{
+			Name:      s.GetName(),
+			Kind:      s.GetKind(),
+			Type:      "Established",
+			Status:    metav1.ConditionFalse,
+			Reason:    CRDsUpdateBlockedReason,
+			Message:   fmt.Sprintf("Updates to the following CRDs were refused:%s.", blocked),
+			Available: false,
+		}
+	}
+
+	message := ""
+	if missing != "" {
+		message = fmt.Sprintf("The following CRDs are missing:%s.", missing)
+	}
+	if notEstablished != "" {
+		message = fmt.Sprintf("%s The following CRDs are not established:%s.", message, notEstablished)
+	}
+	return bpv1.ComponentCondition{
+		Name:      s.GetName(),
+		Kind:      s.GetKind(),
+		Type:      "Established",
+		Status:    metav1.ConditionFalse,
+		Reason:    CRDsNotEstablishedReason,
+		Message:   strings.TrimSpace(message),
+		Available: false,
+	}
+}
+
+// crdEstablished returns true if the CRD has an Established condition set to true. Otherwise it
+// returns the reason the CRD is not yet established.
+func crdEstablished(crd *apixv1.CustomResourceDefinition) (bool, string) {
+	for _, c := range crd.Status.Conditions {
+		if c.Type == apixv1.NamesAccepted && c.Status == apixv1.ConditionFalse {
+			return false, c.Message
+		}
+	}
+	for _, c := range crd.Status.Conditions {
+		if c.Type == apixv1.Established {
+			if c.Status == apixv1.ConditionTrue {
+				return true, ""
+			}
+			return false, c.Message
+		}
+	}
+	return false, "waiting for CRD to be established"
+}
@@ -0,0 +1,140 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stolostron/backplane-operator/pkg/utils"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newCRD(name string, conditions ...apixv1.CustomResourceDefinitionCondition) *apixv1.CustomResourceDefinition {
+	return &apixv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     apixv1.CustomResourceDefinitionStatus{Conditions: conditions},
+	}
+}
+
+func desiredCRDs(names ...string) []*unstructured.Unstructured {
+	desired := []*unstructured.Unstructured{}
+	for _, name := range names {
+		u := &unstructured.Unstructured{}
+		u.SetName(name)
+		u.Object["spec"] = map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1beta1", "served": false, "storage": false},
+				map[string]interface{}{"name": "v1", "served": true, "storage": true},
+			},
+		}
+		desired = append(desired, u)
+	}
+	return desired
+}
+
+func Test_CRDStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apixv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add apiextensions to scheme: %v", err)
+	}
+
+	established := apixv1.CustomResourceDefinitionCondition{Type: apixv1.Established, Status: apixv1.ConditionTrue}
+	notEstablished := apixv1.CustomResourceDefinitionCondition{
+		Type: apixv1.Established, Status: apixv1.ConditionFalse, Message: "installing",
+	}
+	namesRejected := apixv1.CustomResourceDefinitionCondition{
+		Type: apixv1.NamesAccepted, Status: apixv1.ConditionFalse, Message: "name conflict",
+	}
+	storedAlpha := newCRD("b.test.io", established)
+	storedAlpha.Spec.Versions = []apixv1.CustomResourceDefinitionVersion{
+		{Name: "v1alpha1", Served: true, Storage: true},
+	}
+	storedAlpha.Status.StoredVersions = []string{"v1alpha1"}
+	migrating := newCRD("b.test.io", established)
+	migrating.Spec.Versions = []apixv1.CustomResourceDefinitionVersion{
+		{Name: "v1beta1", Served: true}, {Name: "v1", Served: true, Storage: true},
+	}
+	migrating.Status.StoredVersions = []string{"v1beta1", "v1"}
+	ignored := newCRD("ignored.test.io")
+	ignored.SetAnnotations(map[string]string{utils.AnnotationMCEIgnore: "true"})
+
+	tests := []struct {
+		name          string
+		crds          []client.Object
+		wantAvailable bool
+		wantReason    string
+		wantMessage   []string
+	}{
+		{
+			name:          "all established",
+			crds:          []client.Object{newCRD("a.test.io", established), newCRD("b.test.io", established)},
+			wantAvailable: true,
+			wantReason:    CRDsEstablishedReason,
+		},
+		{
+			name:          "missing CRD",
+			crds:          []client.Object{newCRD("a.test.io", established)},
+			wantAvailable: false,
+			wantReason:    CRDsNotEstablishedReason,
+			wantMessage:   []string{"missing", "b.test.io"},
+		},
+		{
+			name:          "CRD not established",
+			crds:          []client.Object{newCRD("a.test.io", established), newCRD("b.test.io", notEstablished)},
+			wantAvailable: false,
+			wantReason:    CRDsNotEstablishedReason,
+			wantMessage:   []string{"not established", "b.test.io: installing"},
+		},
+		{
+			name:          "CRD names not accepted",
+			crds:          []client.Object{newCRD("a.test.io", established), newCRD("b.test.io", namesRejected, established)},
+			wantAvailable: false,
+			wantReason:    CRDsNotEstablishedReason,
+			wantMessage:   []string{"b.test.io: name conflict"},
+		},
+		{
+			name:          "update removes stored version",
+			crds:          []client.Object{newCRD("a.test.io", established), storedAlpha},
+			wantAvailable: false,
+			wantReason:    CRDsUpdateBlockedReason,
+			wantMessage:   []string{"b.test.io: stored versions [v1alpha1]"},
+		},
+		{
+			name:          "stored versions migrating",
+			crds:          []client.Object{newCRD("a.test.io", established), migrating},
+			wantAvailable: true,
+			wantReason:    CRDsEstablishedReason,
+			wantMessage:   []string{"migrating", "b.test.io"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.crds...).Build()
+			got := NewCRDStatus(desiredCRDs("a.test.io", "b.test.io")).Status(cl)
+			if got.Available != tt.wantAvailable {
+				t.Errorf("CRDStatus.Status() available = %v, want %v", got.Available, tt.wantAvailable)
+			}
+			if got.Reason != tt.wantReason {
+				t.Errorf("CRDStatus.Status() reason = %v, want %v", got.Reason, tt.wantReason)
+			}
+			for _, m := range tt.wantMessage {
+				if !strings.Contains(got.Message, m) {
+					t.Errorf("CRDStatus.Status() message = %q, want it to contain %q", got.Message, m)
+				}
+			}
+		})
+	}
+
+	t.Run("ignored CRD is not checked", func(t *testing.T) {
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ignored).Build()
+		got := NewCRDStatus(desiredCRDs("ignored.test.io")).Status(cl)
+		if !got.Available {
+			t.Errorf("CRDStatus.Status() should ignore CRDs with the ignore annotation, got %v", got)
+		}
+	})
+}
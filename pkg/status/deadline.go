@@ -0,0 +1,69 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultReadinessDeadline is how long a component may be unavailable when the MultiClusterEngine
+// sets no deadline for it
+const DefaultReadinessDeadline = 20 * time.Minute
+
+// readinessDeadline returns how long the component may be unavailable
+func readinessDeadline(mce bpv1.MultiClusterEngine, c bpv1.ComponentCondition) time.Duration {
+	deadlines := mce.Spec.ReadinessDeadlines
+	if deadlines == nil {
+		return DefaultReadinessDeadline
+	}
+	for _, d := range deadlines.Components {
+		if d.Name == c.Name && (d.Kind == "" || d.Kind == c.Kind) {
+			return d.Deadline.Duration
+		}
+	}
+	if deadlines.Default != nil {
+		return deadlines.Default.Duration
+	}
+	return DefaultReadinessDeadline
+}
+
+// overdueComponents describes each component that has been unavailable for longer than its deadline,
+// measured from the last transition of its status
+func overdueComponents(mce bpv1.MultiClusterEngine, components []bpv1.ComponentCondition, now time.Time) []string {
+	overdue := []string{}
+	for _, c := range components {
+		if c.Available || c.LastTransitionTime.IsZero() {
+			continue
+		}
+		waited := now.Sub(c.LastTransitionTime.Time)
+		if waited <= readinessDeadline(mce, c) {
+			continue
+		}
+		lastObserved := c.Reason
+		if c.Message != "" {
+			lastObserved = fmt.Sprintf("%s: %s", c.Reason, c.Message)
+		}
+		overdue = append(overdue, fmt.Sprintf("%s %s has been unavailable for %s (%s)",
+			c.Kind, c.Name, waited.Truncate(time.Second), lastObserved))
+	}
+	return overdue
+}
+
+// reportDeadlines sets the failure condition when a component has missed its readiness deadline.
+// Deadlines aren't enforced while the MultiClusterEngine is paused or being deleted.
+func (sm *StatusTracker) reportDeadlines(mce bpv1.MultiClusterEngine, components []bpv1.ComponentCondition, phase bpv1.PhaseType) {
+	overdue := []string{}
+	if phase != bpv1.MultiClusterEnginePhasePaused && phase != bpv1.MultiClusterEnginePhaseUninstalling {
+		overdue = overdueComponents(mce, components, time.Now())
+	}
+	if len(overdue) > 0 {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineFailure, metav1.ConditionTrue, ReadinessDeadlineExceededReason,
+			strings.Join(overdue, "; ")))
+	} else {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineFailure, metav1.ConditionFalse, AsExpectedReason, ""))
+	}
+}
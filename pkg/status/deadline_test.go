@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_readinessDeadline(t *testing.T) {
+	mce := bpv1.MultiClusterEngine{}
+	component := bpv1.ComponentCondition{Name: "cluster-manager", Kind: "Deployment"}
+
+	if got := readinessDeadline(mce, component); got != DefaultReadinessDeadline {
+		t.Errorf("readinessDeadline() without deadlines = %v, want %v", got, DefaultReadinessDeadline)
+	}
+
+	mce.Spec.ReadinessDeadlines = &bpv1.ReadinessDeadlines{
+		Default: &metav1.Duration{Duration: 5 * time.Minute},
+		Components: []bpv1.ComponentDeadline{
+			{Name: "cluster-manager", Kind: "ClusterManager", Deadline: metav1.Duration{Duration: time.Hour}},
+			{Name: "hypershift-addon-manager", Deadline: metav1.Duration{Duration: 30 * time.Minute}},
+		},
+	}
+	tests := []struct {
+		name      string
+		component bpv1.ComponentCondition
+		want      time.Duration
+	}{
+		{
+			name:      "default",
+			component: component,
+			want:      5 * time.Minute,
+		},
+		{
+			name:      "name and kind match",
+			component: bpv1.ComponentCondition{Name: "cluster-manager", Kind: "ClusterManager"},
+			want:      time.Hour,
+		},
+		{
+			name:      "name matches any kind",
+			component: bpv1.ComponentCondition{Name: "hypershift-addon-manager", Kind: "Deployment"},
+			want:      30 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readinessDeadline(mce, tt.component); got != tt.want {
+				t.Errorf("readinessDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusTracker_ReportStatusDeadlines(t *testing.T) {
+	anHourAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	unavailable := bpv1.ComponentCondition{
+		Name:    "cluster-manager",
+		Kind:    "Deployment",
+		Type:    "Available",
+		Status:  metav1.ConditionFalse,
+		Reason:  "MinimumReplicasUnavailable",
+		Message: "0 of 1 replicas ready",
+	}
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.AddComponent(MockStatus{
+		NamespacedName: types.NamespacedName{Name: "cluster-manager", Namespace: "mock-ns"},
+		statusFunc:     func() bpv1.ComponentCondition { return unavailable },
+	})
+
+	previous := unavailable
+	previous.LastTransitionTime = anHourAgo
+	mce := bpv1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Status:     bpv1.MultiClusterEngineStatus{Components: []bpv1.ComponentCondition{previous}},
+	}
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		status := tracker.ReportStatus(mce)
+		c := getCondition(status.Conditions, bpv1.MultiClusterEngineFailure)
+		if c == nil || c.Status != metav1.ConditionTrue || c.Reason != ReadinessDeadlineExceededReason {
+			t.Fatalf("expected failure condition to be set, got %+v", c)
+		}
+		want := "Deployment cluster-manager has been unavailable for 1h0m"
+		if !strings.HasPrefix(c.Message, want) {
+			t.Errorf("failure message = %q, want prefix %q", c.Message, want)
+		}
+		if !strings.HasSuffix(c.Message, "(MinimumReplicasUnavailable: 0 of 1 replicas ready)") {
+			t.Errorf("failure message = %q, want the last observed reason", c.Message)
+		}
+	})
+
+	t.Run("deadline overridden", func(t *testing.T) {
+		mce.Spec.ReadinessDeadlines = &bpv1.ReadinessDeadlines{
+			Components: []bpv1.ComponentDeadline{{Name: "cluster-manager", Deadline: metav1.Duration{Duration: 2 * time.Hour}}},
+		}
+		status := tracker.ReportStatus(mce)
+		c := getCondition(status.Conditions, bpv1.MultiClusterEngineFailure)
+		if c == nil || c.Status != metav1.ConditionFalse {
+			t.Errorf("expected failure condition to be false, got %+v", c)
+		}
+	})
+
+	t.Run("not enforced while paused", func(t *testing.T) {
+		mce.Spec.ReadinessDeadlines = nil
+		mce.SetAnnotations(map[string]string{"pause": "true"})
+		status := tracker.ReportStatus(mce)
+		c := getCondition(status.Conditions, bpv1.MultiClusterEngineFailure)
+		if c == nil || c.Status != metav1.ConditionFalse {
+			t.Errorf("expected failure condition to be false, got %+v", c)
+		}
+	})
+}
@@ -42,7 +42,141 @@ func (ds DeploymentStatus) Status(k8sClient client.Client) bpv1.ComponentConditi
 		return unknownStatus(ds.GetName(), ds.GetKind())
 	}
 
-	return mapDeployment(deploy)
+	return withPodFailure(k8sClient, mapDeployment(deploy), deploy.Namespace, deploy.Spec.Selector)
+}
+
+// withPodFailure replaces the reason and message of an unavailable workload with why its pods are failing,
+// which the workload's own status does not tell
+func withPodFailure(k8sClient client.Client, cc bpv1.ComponentCondition, namespace string, selector *metav1.LabelSelector) bpv1.ComponentCondition {
+	if cc.Available {
+		return cc
+	}
+	pods, err := selectedPods(k8sClient, namespace, selector)
+	if err != nil {
+		fmt.Println("Err listing pods of", cc.Kind, cc.Name, err)
+		return cc
+	}
+	if failure, ok := dominantPodFailure(pods); ok {
+		cc.Reason = failure.Reason
+		cc.Message = failure.String()
+	}
+	return cc
+}
+
+// Pod failure reasons surfaced in the status of an unavailable deployment, by priority when as many pods
+// fail for each
+var podFailureReasons = []string{
+	"ImagePullBackOff",
+	"ErrImagePull",
+	"InvalidImageName",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+	"OOMKilled",
+	"CrashLoopBackOff",
+	corev1.PodReasonUnschedulable,
+}
+
+// podFailure is the reason a container or pod is failing
+type podFailure struct {
+	Reason string
+	// Pod is the name of the pod
+	Pod string
+	// Container is the name of the container, or empty when the pod failed to be scheduled
+	Container string
+	Message   string
+}
+
+func (f podFailure) String() string {
+	subject := fmt.Sprintf("pod %s", f.Pod)
+	if f.Container != "" {
+		subject = fmt.Sprintf("container %s of pod %s", f.Container, f.Pod)
+	}
+	if f.Message == "" {
+		return fmt.Sprintf("%s: %s", subject, f.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", subject, f.Reason, f.Message)
+}
+
+func selectedPods(k8sClient client.Client, namespace string, labelSelector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	if labelSelector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	pods := &corev1.PodList{}
+	err = k8sClient.List(context.TODO(), pods, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
+	return pods.Items, err
+}
+
+// dominantPodFailure returns the failure shared by the most pods, with an example pod and container
+func dominantPodFailure(pods []corev1.Pod) (podFailure, bool) {
+	counts := map[string]int{}
+	examples := map[string]podFailure{}
+	for i := range pods {
+		// Count each reason once per pod
+		seen := map[string]bool{}
+		for _, f := range podFailures(&pods[i]) {
+			if seen[f.Reason] {
+				continue
+			}
+			seen[f.Reason] = true
+			counts[f.Reason]++
+			if _, ok := examples[f.Reason]; !ok {
+				examples[f.Reason] = f
+			}
+		}
+	}
+
+	dominant := ""
+	for _, reason := range podFailureReasons {
+		if counts[reason] > counts[dominant] {
+			dominant = reason
+		}
+	}
+	if dominant == "" {
+		return podFailure{}, false
+	}
+	return examples[dominant], true
+}
+
+// podFailures returns the known failures of a pod and its containers
+func podFailures(pod *corev1.Pod) []podFailure {
+	failures := []podFailure{}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			failures = append(failures, podFailure{Reason: c.Reason, Pod: pod.Name, Message: c.Message})
+		}
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		// A container restarted after running out of memory is reported as such rather than as crash looping
+		if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" && !cs.Ready {
+			failures = append(failures, podFailure{Reason: t.Reason, Pod: pod.Name, Container: cs.Name,
+				Message: fmt.Sprintf("exited with code %d, restarted %d times", t.ExitCode, cs.RestartCount)})
+			continue
+		}
+		if t := cs.State.Terminated; t != nil && t.Reason == "OOMKilled" {
+			failures = append(failures, podFailure{Reason: t.Reason, Pod: pod.Name, Container: cs.Name,
+				Message: fmt.Sprintf("exited with code %d", t.ExitCode)})
+			continue
+		}
+		if w := cs.State.Waiting; w != nil && knownPodFailure(w.Reason) {
+			failures = append(failures, podFailure{Reason: w.Reason, Pod: pod.Name, Container: cs.Name, Message: w.Message})
+		}
+	}
+	return failures
+}
+
+func knownPodFailure(reason string) bool {
+	for _, r := range podFailureReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
 }
 
 func mapDeployment(ds *appsv1.Deployment) bpv1.ComponentCondition {
@@ -8,6 +8,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_mapDeployment(t *testing.T) {
@@ -103,3 +105,111 @@ func Test_mapDeployment(t *testing.T) {
 		})
 	}
 }
+
+func Test_dominantPodFailure(t *testing.T) {
+	waiting := func(name, container, reason, message string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  container,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+			}}},
+		}
+	}
+	oomKilled := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-oom"},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:                 "manager",
+			RestartCount:         4,
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+		}}},
+	}
+	unschedulable := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-pending"},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  corev1.PodReasonUnschedulable,
+			Message: "0/3 nodes are available: 3 Insufficient memory.",
+		}}},
+	}
+
+	tests := []struct {
+		name string
+		pods []corev1.Pod
+		want string
+	}{
+		{
+			name: "no failures",
+			pods: []corev1.Pod{waiting("pod-1", "manager", "ContainerCreating", "")},
+		},
+		{
+			name: "image pull failure",
+			pods: []corev1.Pod{waiting("pod-1", "manager", "ImagePullBackOff", `Back-off pulling image "quay.io/stolostron/foo"`)},
+			want: `container manager of pod pod-1: ImagePullBackOff: Back-off pulling image "quay.io/stolostron/foo"`,
+		},
+		{
+			name: "most common reason wins",
+			pods: []corev1.Pod{
+				waiting("pod-1", "manager", "ImagePullBackOff", ""),
+				waiting("pod-2", "manager", "CrashLoopBackOff", ""),
+				waiting("pod-3", "manager", "CrashLoopBackOff", ""),
+			},
+			want: "container manager of pod pod-2: CrashLoopBackOff",
+		},
+		{
+			name: "out of memory rather than crash looping",
+			pods: []corev1.Pod{oomKilled},
+			want: "container manager of pod pod-oom: OOMKilled: exited with code 137, restarted 4 times",
+		},
+		{
+			name: "unschedulable",
+			pods: []corev1.Pod{unschedulable},
+			want: "pod pod-pending: Unschedulable: 0/3 nodes are available: 3 Insufficient memory.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := dominantPodFailure(tt.pods)
+			if ok != (tt.want != "") {
+				t.Fatalf("dominantPodFailure() found = %v, want %v", ok, tt.want != "")
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("dominantPodFailure() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDeploymentStatus_PodFailure(t *testing.T) {
+	labels := map[string]string{"app": "test"}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "test"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		Status: appsv1.DeploymentStatus{
+			UnavailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentAvailable,
+				Status: corev1.ConditionFalse,
+				Reason: "MinimumReplicasUnavailable",
+			}},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test", Labels: labels},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "manager",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "not found"}},
+		}}},
+	}
+	cl := fake.NewClientBuilder().WithObjects(deploy, pod).Build()
+
+	got := DeploymentStatus{NamespacedName: types.NamespacedName{Name: "test-deployment", Namespace: "test"}}.Status(cl)
+	if got.Available {
+		t.Fatal("expected the deployment to be unavailable")
+	}
+	if got.Reason != "ErrImagePull" || got.Message != "container manager of pod test-pod: ErrImagePull: not found" {
+		t.Errorf("expected the pod failure in the status, got reason %q message %q", got.Reason, got.Message)
+	}
+}
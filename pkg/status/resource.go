@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"context"
+	"fmt"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewResourceStatus returns a StatusReporter that checks the health of a rendered resource, or nil if
+// there is no health check for its kind
+func NewResourceStatus(u *unstructured.Unstructured) StatusReporter {
+	nn := types.NamespacedName{Name: u.GetName(), Namespace: u.GetNamespace()}
+	switch u.GroupVersionKind().GroupKind() {
+	case statefulSetGVK.GroupKind():
+		return StatefulSetStatus{NamespacedName: nn}
+	case daemonSetGVK.GroupKind():
+		return DaemonSetStatus{NamespacedName: nn}
+	case jobGVK.GroupKind():
+		return JobStatus{NamespacedName: nn}
+	case apiServiceGVK.GroupKind():
+		return APIServiceStatus{NamespacedName: nn}
+	case validatingWebhookGVK.GroupKind(), mutatingWebhookGVK.GroupKind():
+		return WebhookConfigurationStatus{NamespacedName: nn, Kind: u.GetKind()}
+	case consolePluginGroupKind:
+		return ConsolePluginStatus{NamespacedName: nn, Version: u.GroupVersionKind().Version}
+	}
+	return nil
+}
+
+// getResource reads a resource into obj, either an unstructured object or a typed object it is converted
+// into. When the resource can't be read it returns the condition to report instead.
+func getResource(k8sClient client.Client, nn types.NamespacedName, gvk schema.GroupVersionKind, obj interface{}) *bpv1.ComponentCondition {
+	u, isUnstructured := obj.(*unstructured.Unstructured)
+	if isUnstructured {
+		u.SetGroupVersionKind(gvk)
+	} else {
+		u = newUnstructured(nn, gvk)
+	}
+	err := k8sClient.Get(context.TODO(), nn, u)
+	if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		resourceName := nn.Name
+		if nn.Namespace != "" {
+			resourceName = fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)
+		}
+		cc := availableStatus(nn.Name, gvk.Kind, false, DeployFailedReason,
+			fmt.Sprintf("The following resource is missing: <%s %s>", gvk.Kind, resourceName))
+		return &cc
+	}
+	if err != nil {
+		fmt.Println("Err getting", gvk.Kind, nn, err)
+		cc := unknownStatus(nn.Name, gvk.Kind)
+		return &cc
+	}
+	if isUnstructured {
+		return nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		fmt.Println("Err converting", gvk.Kind, nn, err)
+		cc := unknownStatus(nn.Name, gvk.Kind)
+		return &cc
+	}
+	return nil
+}
+
+// availableStatus returns the condition of a resource that is available or not
+func availableStatus(name, kind string, available bool, reason, message string) bpv1.ComponentCondition {
+	status := metav1.ConditionFalse
+	if available {
+		status = metav1.ConditionTrue
+	}
+	return bpv1.ComponentCondition{
+		Name:      name,
+		Kind:      kind,
+		Type:      "Available",
+		Status:    status,
+		Reason:    reason,
+		Message:   message,
+		Available: available,
+	}
+}
@@ -2,7 +2,11 @@
 package status
 
 import (
+	"fmt"
+	"strings"
+
 	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/utils"
 	"github.com/stolostron/backplane-operator/pkg/version"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,8 +56,14 @@ func (sm *StatusTracker) AddCondition(c bpv1.MultiClusterEngineCondition) {
 	sm.Conditions = setCondition(sm.Conditions, c)
 }
 
+// RemoveCondition removes the condition of the given type, if present
+func (sm *StatusTracker) RemoveCondition(condType bpv1.MultiClusterEngineConditionType) {
+	sm.Conditions = filterOutCondition(sm.Conditions, condType)
+}
+
 func (sm *StatusTracker) ReportStatus(mce bpv1.MultiClusterEngine) bpv1.MultiClusterEngineStatus {
 	components := sm.reportComponents()
+	preserveTransitionTimes(components, mce.Status.Components)
 
 	// Infer available condition from component health
 	if allComponentsReady(components) {
@@ -63,13 +73,15 @@ func (sm *StatusTracker) ReportStatus(mce bpv1.MultiClusterEngine) bpv1.MultiClu
 		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineAvailable, metav1.ConditionFalse, ComponentsUnavailableReason, ""))
 	}
 
-	conditions := sm.reportConditions()
-	phase := sm.reportPhase(mce, components, conditions)
+	phase := sm.reportPhase(mce, components, sm.reportConditions())
 
 	currentVersion := mce.Status.CurrentVersion
 	if phase == bpv1.MultiClusterEnginePhaseAvailable {
 		currentVersion = version.Version
 	}
+	sm.reportPhaseConditions(mce, components, phase, currentVersion)
+	sm.reportDeadlines(mce, components, phase)
+	conditions := sm.reportConditions()
 
 	return bpv1.MultiClusterEngineStatus{
 		Components:     components,
@@ -88,11 +100,37 @@ func (sm *StatusTracker) reportComponents() []bpv1.ComponentCondition {
 	return components
 }
 
+// preserveTransitionTimes keeps the transition time of each component from its previous status unless
+// its status or type changed, since reporters build their conditions anew on every reconcile
+func preserveTransitionTimes(components, previous []bpv1.ComponentCondition) {
+	now := metav1.Now()
+	for i := range components {
+		c := &components[i]
+		for _, p := range previous {
+			if p.Name != c.Name || p.Kind != c.Kind {
+				continue
+			}
+			if p.Status == c.Status && p.Type == c.Type && !p.LastTransitionTime.IsZero() {
+				c.LastTransitionTime = p.LastTransitionTime
+			}
+			break
+		}
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = now
+		}
+	}
+}
+
 func (sm *StatusTracker) reportConditions() []bpv1.MultiClusterEngineCondition {
 	return sm.Conditions
 }
 
 func (sm *StatusTracker) reportPhase(mce bpv1.MultiClusterEngine, components []bpv1.ComponentCondition, conditions []bpv1.MultiClusterEngineCondition) bpv1.PhaseType {
+	// If reconciliation is paused show paused phase, since components are no longer being tracked
+	if utils.IsPaused(&mce) && mce.GetDeletionTimestamp() == nil {
+		return bpv1.MultiClusterEnginePhasePaused
+	}
+
 	progress := getCondition(conditions, bpv1.MultiClusterEngineProgressing)
 
 	// If operator isn't progressing show error phase
@@ -110,14 +148,73 @@ func (sm *StatusTracker) reportPhase(mce bpv1.MultiClusterEngine, components []b
 		return bpv1.MultiClusterEnginePhaseError
 	}
 
-	// If a component isn't ready show progressing phase
+	// If a component isn't ready show whether it is rolling out a new version, was available at this
+	// version before, or is still being installed, such as a newly enabled component
 	if !allComponentsReady(components) {
+		switch {
+		case upgrading(mce.Status.CurrentVersion):
+			return bpv1.MultiClusterEnginePhaseUpgrading
+		case mce.Status.CurrentVersion == version.Version && len(degradedComponents(mce.Status, components)) > 0:
+			return bpv1.MultiClusterEnginePhaseDegraded
+		}
 		return bpv1.MultiClusterEnginePhaseProgressing
 	}
 
 	return bpv1.MultiClusterEnginePhaseAvailable
 }
 
+// reportPhaseConditions sets the Degraded, Paused and Upgrading conditions so that they can be watched
+// independently of the phase, which only shows the most pressing of them
+func (sm *StatusTracker) reportPhaseConditions(mce bpv1.MultiClusterEngine, components []bpv1.ComponentCondition, phase bpv1.PhaseType, currentVersion string) {
+	if phase == bpv1.MultiClusterEnginePhaseDegraded {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineDegraded, metav1.ConditionTrue, ComponentsDegradedReason,
+			fmt.Sprintf("The following components are unavailable: %s", strings.Join(degradedComponents(mce.Status, components), ", "))))
+	} else {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineDegraded, metav1.ConditionFalse, AsExpectedReason, ""))
+	}
+
+	if utils.IsPaused(&mce) {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEnginePaused, metav1.ConditionTrue, PausedReason, "Multiclusterengine is paused"))
+	} else {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEnginePaused, metav1.ConditionFalse, AsExpectedReason, ""))
+	}
+
+	if upgrading(currentVersion) {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineUpgrading, metav1.ConditionTrue, UpgradeInProgressReason,
+			fmt.Sprintf("Upgrading from %s to %s", currentVersion, version.Version)))
+	} else {
+		sm.AddCondition(NewCondition(bpv1.MultiClusterEngineUpgrading, metav1.ConditionFalse, AsExpectedReason, ""))
+	}
+}
+
+// upgrading returns true if another version was installed and this version has not been yet
+func upgrading(currentVersion string) bool {
+	return currentVersion != "" && currentVersion != version.Version
+}
+
+// degradedComponents lists the kind and name of each component that isn't available but was reported
+// available in the previous status, or was already unavailable while the previous phase was Degraded.
+// Availability is read from the persisted Available field, as the condition status of a component
+// can be True while it is still rolling out, such as a Deployment that is Progressing.
+func degradedComponents(previous bpv1.MultiClusterEngineStatus, components []bpv1.ComponentCondition) []string {
+	degraded := []string{}
+	for _, c := range components {
+		if c.Available {
+			continue
+		}
+		for _, p := range previous.Components {
+			if p.Name != c.Name || p.Kind != c.Kind {
+				continue
+			}
+			if p.Available || previous.Phase == bpv1.MultiClusterEnginePhaseDegraded {
+				degraded = append(degraded, fmt.Sprintf("%s %s", c.Kind, c.Name))
+			}
+			break
+		}
+	}
+	return degraded
+}
+
 func allComponentsReady(components []bpv1.ComponentCondition) bool {
 	if len(components) == 0 {
 		return false
@@ -3,6 +3,7 @@ package status
 
 import (
 	"testing"
+	"time"
 
 	bpv1 "github.com/stolostron/backplane-operator/api/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -133,11 +134,27 @@ func Test_AddCondition(t *testing.T) {
 }
 
 func TestStatusTracker_ReportStatus(t *testing.T) {
+	unavailable := MockStatus{
+		NamespacedName: types.NamespacedName{Name: "mock-name", Namespace: "mock-ns"},
+		statusFunc: func() bpv1.ComponentCondition {
+			return bpv1.ComponentCondition{
+				Name:      "mock-name",
+				Kind:      "Deployment",
+				Type:      "Available",
+				Status:    metav1.ConditionFalse,
+				Reason:    "MinimumReplicasUnavailable",
+				Available: false,
+			}
+		},
+	}
+
 	tests := []struct {
-		name       string
-		Components []StatusReporter
-		Conditions []bpv1.MultiClusterEngineCondition
-		want       bpv1.MultiClusterEngineStatus
+		name        string
+		Components  []StatusReporter
+		Conditions  []bpv1.MultiClusterEngineCondition
+		Annotations map[string]string
+		Previous    bpv1.MultiClusterEngineStatus
+		want        bpv1.MultiClusterEngineStatus
 	}{
 		{
 			name: "Single running deployment",
@@ -203,21 +220,88 @@ func TestStatusTracker_ReportStatus(t *testing.T) {
 				Phase:          bpv1.MultiClusterEnginePhaseError,
 			},
 		},
+		{
+			name:       "Component unavailable after install",
+			Components: []StatusReporter{unavailable},
+			Previous: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.9",
+				Components: []bpv1.ComponentCondition{
+					{Name: "mock-name", Kind: "Deployment", Status: metav1.ConditionTrue, Available: true},
+				},
+			},
+			want: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.9",
+				DesiredVersion: "9.9.9",
+				Phase:          bpv1.MultiClusterEnginePhaseDegraded,
+			},
+		},
+		{
+			name:       "Component rolling out after install",
+			Components: []StatusReporter{unavailable},
+			Previous: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.9",
+				Components: []bpv1.ComponentCondition{
+					{Name: "mock-name", Kind: "Deployment", Type: "Progressing", Status: metav1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+				},
+			},
+			want: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.9",
+				DesiredVersion: "9.9.9",
+				Phase:          bpv1.MultiClusterEnginePhaseProgressing,
+			},
+		},
+		{
+			name:       "Component added after install",
+			Components: []StatusReporter{unavailable},
+			Previous:   bpv1.MultiClusterEngineStatus{CurrentVersion: "9.9.9"},
+			want: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.9",
+				DesiredVersion: "9.9.9",
+				Phase:          bpv1.MultiClusterEnginePhaseProgressing,
+			},
+		},
+		{
+			name:       "Component unavailable during upgrade",
+			Components: []StatusReporter{unavailable},
+			Previous:   bpv1.MultiClusterEngineStatus{CurrentVersion: "9.9.8"},
+			want: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.8",
+				DesiredVersion: "9.9.9",
+				Phase:          bpv1.MultiClusterEnginePhaseUpgrading,
+			},
+		},
+		{
+			name:        "Paused",
+			Components:  nil,
+			Conditions:  []bpv1.MultiClusterEngineCondition{NewCondition(bpv1.MultiClusterEngineProgressing, metav1.ConditionUnknown, PausedReason, "")},
+			Annotations: map[string]string{"pause": "true"},
+			Previous:    bpv1.MultiClusterEngineStatus{CurrentVersion: "9.9.9"},
+			want: bpv1.MultiClusterEngineStatus{
+				CurrentVersion: "9.9.9",
+				DesiredVersion: "9.9.9",
+				Phase:          bpv1.MultiClusterEnginePhasePaused,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
 			backplane := bpv1.MultiClusterEngine{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "test",
+					Name:        "test",
+					Annotations: tt.Annotations,
 				},
 				Spec: bpv1.MultiClusterEngineSpec{
 					TargetNamespace: "mock-ns",
 				},
+				Status: tt.Previous,
 			}
 			for _, c := range tt.Components {
 				tracker.AddComponent(c)
 			}
+			for _, c := range tt.Conditions {
+				tracker.AddCondition(c)
+			}
 
 			got := tracker.ReportStatus(backplane)
 
@@ -234,6 +318,122 @@ func TestStatusTracker_ReportStatus(t *testing.T) {
 	}
 }
 
+func TestStatusTracker_ReportStatusPhaseConditions(t *testing.T) {
+	expectCondition := func(t *testing.T, status bpv1.MultiClusterEngineStatus, condType bpv1.MultiClusterEngineConditionType,
+		want metav1.ConditionStatus, wantMessage string) {
+		t.Helper()
+		c := getCondition(status.Conditions, condType)
+		if c == nil {
+			t.Fatalf("expected %s condition to be reported", condType)
+		}
+		if c.Status != want || c.Message != wantMessage {
+			t.Errorf("%s condition = %s %q, want %s %q", condType, c.Status, c.Message, want, wantMessage)
+		}
+	}
+
+	mce := bpv1.MultiClusterEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Status:     bpv1.MultiClusterEngineStatus{CurrentVersion: "9.9.8"},
+	}
+	available := true
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.AddComponent(MockStatus{
+		NamespacedName: types.NamespacedName{Name: "mock-name", Namespace: "mock-ns"},
+		statusFunc: func() bpv1.ComponentCondition {
+			status := metav1.ConditionFalse
+			if available {
+				status = metav1.ConditionTrue
+			}
+			return bpv1.ComponentCondition{Name: "mock-name", Kind: "Deployment", Status: status, Available: available}
+		},
+	})
+
+	t.Run("upgrade completes", func(t *testing.T) {
+		mce.Status = tracker.ReportStatus(mce)
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineUpgrading, metav1.ConditionFalse, "")
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineDegraded, metav1.ConditionFalse, "")
+		expectCondition(t, mce.Status, bpv1.MultiClusterEnginePaused, metav1.ConditionFalse, "")
+	})
+
+	t.Run("component becomes unavailable", func(t *testing.T) {
+		available = false
+		mce.Status = tracker.ReportStatus(mce)
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineDegraded, metav1.ConditionTrue,
+			"The following components are unavailable: Deployment mock-name")
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineUpgrading, metav1.ConditionFalse, "")
+	})
+
+	t.Run("component stays unavailable", func(t *testing.T) {
+		mce.Status = tracker.ReportStatus(mce)
+		if mce.Status.Phase != bpv1.MultiClusterEnginePhaseDegraded {
+			t.Errorf("phase = %s, want %s", mce.Status.Phase, bpv1.MultiClusterEnginePhaseDegraded)
+		}
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineDegraded, metav1.ConditionTrue,
+			"The following components are unavailable: Deployment mock-name")
+	})
+
+	t.Run("paused while degraded", func(t *testing.T) {
+		mce.SetAnnotations(map[string]string{"pause": "true"})
+		mce.Status = tracker.ReportStatus(mce)
+		if mce.Status.Phase != bpv1.MultiClusterEnginePhasePaused {
+			t.Errorf("phase = %s, want %s", mce.Status.Phase, bpv1.MultiClusterEnginePhasePaused)
+		}
+		expectCondition(t, mce.Status, bpv1.MultiClusterEnginePaused, metav1.ConditionTrue, "Multiclusterengine is paused")
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineDegraded, metav1.ConditionFalse, "")
+	})
+
+	t.Run("upgrade starts", func(t *testing.T) {
+		mce.SetAnnotations(nil)
+		mce.Status.CurrentVersion = "9.9.8"
+		mce.Status = tracker.ReportStatus(mce)
+		expectCondition(t, mce.Status, bpv1.MultiClusterEngineUpgrading, metav1.ConditionTrue, "Upgrading from 9.9.8 to 9.9.9")
+		expectCondition(t, mce.Status, bpv1.MultiClusterEnginePaused, metav1.ConditionFalse, "")
+	})
+}
+
+func TestStatusTracker_ReportStatusTransitionTimes(t *testing.T) {
+	earlier := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	componentStatus := metav1.ConditionStatus("True")
+	tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
+	tracker.AddComponent(MockStatus{
+		NamespacedName: types.NamespacedName{Name: "mock-name", Namespace: "mock-ns"},
+		statusFunc: func() bpv1.ComponentCondition {
+			return bpv1.ComponentCondition{
+				Name:               "mock-name",
+				Kind:               "Deployment",
+				Type:               "Available",
+				Status:             componentStatus,
+				LastTransitionTime: metav1.Now(),
+				Available:          componentStatus == "True",
+			}
+		},
+	})
+	tracker.AddComponent(StaticStatus{
+		NamespacedName: types.NamespacedName{Name: "static"},
+		Condition:      bpv1.ComponentCondition{Name: "static", Type: "Present", Status: metav1.ConditionTrue, Available: true},
+	})
+
+	mce := bpv1.MultiClusterEngine{Status: bpv1.MultiClusterEngineStatus{
+		Components: []bpv1.ComponentCondition{
+			{Name: "mock-name", Kind: "Deployment", Type: "Available", Status: "True", LastTransitionTime: earlier},
+		},
+	}}
+
+	got := tracker.ReportStatus(mce)
+	if !got.Components[0].LastTransitionTime.Equal(&earlier) {
+		t.Errorf("expected the transition time of an unchanged component to be kept, got %v", got.Components[0].LastTransitionTime)
+	}
+	if got.Components[1].LastTransitionTime.IsZero() {
+		t.Error("expected a transition time for a component reported without one")
+	}
+
+	componentStatus = "False"
+	got = tracker.ReportStatus(mce)
+	if got.Components[0].LastTransitionTime.Equal(&earlier) {
+		t.Error("expected the transition time to be updated when the status changes")
+	}
+}
+
 func TestStatusTracker_Reset(t *testing.T) {
 	t.Run("Reset status tracker", func(t *testing.T) {
 		tracker := StatusTracker{Client: fake.NewClientBuilder().Build()}
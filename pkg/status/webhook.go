@@ -0,0 +1,151 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	validatingWebhookGVK   = schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"}
+	mutatingWebhookGVK     = schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "MutatingWebhookConfiguration"}
+	consolePluginGroupKind = schema.GroupKind{Group: "console.openshift.io", Kind: "ConsolePlugin"}
+)
+
+// WebhookConfigurationStatus fulfills the StatusReporter interface for validating and mutating webhook
+// configurations. It is available when every service the webhooks call has ready endpoints.
+type WebhookConfigurationStatus struct {
+	types.NamespacedName
+	// Kind is ValidatingWebhookConfiguration or MutatingWebhookConfiguration
+	Kind string
+}
+
+func (s WebhookConfigurationStatus) GetName() string {
+	return s.Name
+}
+
+func (s WebhookConfigurationStatus) GetNamespace() string {
+	return ""
+}
+
+func (s WebhookConfigurationStatus) GetKind() string {
+	return s.Kind
+}
+
+// Converts the readiness of the services backing the webhooks to a backplane component status
+func (s WebhookConfigurationStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	gvk := validatingWebhookGVK
+	if s.Kind == mutatingWebhookGVK.Kind {
+		gvk = mutatingWebhookGVK
+	}
+	u := &unstructured.Unstructured{}
+	if cc := getResource(k8sClient, types.NamespacedName{Name: s.Name}, gvk, u); cc != nil {
+		return *cc
+	}
+
+	services := map[types.NamespacedName]bool{}
+	webhooks, _, _ := unstructured.NestedSlice(u.Object, "webhooks")
+	for _, w := range webhooks {
+		webhook, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if svc, ok := serviceRef(webhook, "clientConfig", "service"); ok {
+			services[svc] = true
+		}
+	}
+	return servicesStatus(k8sClient, s.Name, s.Kind, services)
+}
+
+// ConsolePluginStatus fulfills the StatusReporter interface for console plugins. It is available when the
+// service serving the plugin has ready endpoints.
+type ConsolePluginStatus struct {
+	types.NamespacedName
+	// Version of the console.openshift.io API the plugin was rendered with
+	Version string
+}
+
+func (s ConsolePluginStatus) GetName() string {
+	return s.Name
+}
+
+func (s ConsolePluginStatus) GetNamespace() string {
+	return ""
+}
+
+func (s ConsolePluginStatus) GetKind() string {
+	return consolePluginGroupKind.Kind
+}
+
+// Converts the readiness of the plugin's backend service to a backplane component status
+func (s ConsolePluginStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	u := &unstructured.Unstructured{}
+	if cc := getResource(k8sClient, types.NamespacedName{Name: s.Name}, consolePluginGroupKind.WithVersion(s.Version), u); cc != nil {
+		return *cc
+	}
+
+	services := map[types.NamespacedName]bool{}
+	// v1 plugins declare their backend under spec.backend, v1alpha1 plugins under spec.service
+	if svc, ok := serviceRef(u.Object, "spec", "backend", "service"); ok {
+		services[svc] = true
+	} else if svc, ok := serviceRef(u.Object, "spec", "service"); ok {
+		services[svc] = true
+	}
+	return servicesStatus(k8sClient, s.Name, s.GetKind(), services)
+}
+
+// serviceRef reads the name and namespace of a service reference at the given path
+func serviceRef(obj map[string]interface{}, fields ...string) (types.NamespacedName, bool) {
+	name, _, _ := unstructured.NestedString(obj, append(fields, "name")...)
+	namespace, _, _ := unstructured.NestedString(obj, append(fields, "namespace")...)
+	return types.NamespacedName{Name: name, Namespace: namespace}, name != ""
+}
+
+// servicesStatus reports a resource as available when each of the services has ready endpoints
+func servicesStatus(k8sClient client.Client, name, kind string, services map[types.NamespacedName]bool) bpv1.ComponentCondition {
+	notReady := []string{}
+	for svc := range services {
+		ready, err := serviceReady(k8sClient, svc)
+		if err != nil {
+			fmt.Println("Err getting endpoints of service", svc, err)
+			return unknownStatus(name, kind)
+		}
+		if !ready {
+			notReady = append(notReady, svc.String())
+		}
+	}
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		return availableStatus(name, kind, false, "ServiceUnavailable",
+			fmt.Sprintf("The following services have no ready endpoints: %s", strings.Join(notReady, ", ")))
+	}
+	return availableStatus(name, kind, true, "ServiceAvailable", "")
+}
+
+// serviceReady returns true if the service has at least one ready endpoint
+func serviceReady(k8sClient client.Client, svc types.NamespacedName) (bool, error) {
+	endpoints := &corev1.Endpoints{}
+	err := k8sClient.Get(context.TODO(), svc, endpoints)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
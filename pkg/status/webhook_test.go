@@ -0,0 +1,92 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWebhookConfigurationStatus(t *testing.T) {
+	webhook := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "admissionregistration.k8s.io/v1",
+		"kind":       "ValidatingWebhookConfiguration",
+		"metadata":   map[string]interface{}{"name": "ocm-validating-webhook"},
+		"webhooks": []interface{}{
+			map[string]interface{}{
+				"name":         "managedclustervalidators.admission.cluster.open-cluster-management.io",
+				"clientConfig": map[string]interface{}{"service": map[string]interface{}{"name": "ocm-webhook", "namespace": "mce"}},
+			},
+		},
+	}}
+	endpoints := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "ocm-webhook", Namespace: "mce"}}
+	cl := fake.NewClientBuilder().WithObjects(webhook, endpoints).Build()
+	reporter := WebhookConfigurationStatus{NamespacedName: types.NamespacedName{Name: "ocm-validating-webhook"}, Kind: "ValidatingWebhookConfiguration"}
+
+	got := reporter.Status(cl)
+	if got.Available || got.Message != "The following services have no ready endpoints: mce/ocm-webhook" {
+		t.Errorf("expected the webhook to be unavailable without ready endpoints, got %v", got)
+	}
+
+	endpoints.Subsets = []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}}
+	if err := cl.Update(context.TODO(), endpoints); err != nil {
+		t.Fatal(err)
+	}
+	if got := reporter.Status(cl); !got.Available {
+		t.Errorf("expected the webhook to be available with ready endpoints, got %v", got)
+	}
+}
+
+func TestConsolePluginStatus(t *testing.T) {
+	plugin := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "console.openshift.io/v1alpha1",
+		"kind":       "ConsolePlugin",
+		"metadata":   map[string]interface{}{"name": "mce"},
+		"spec": map[string]interface{}{
+			"service": map[string]interface{}{"name": "console-mce-console", "namespace": "mce"},
+		},
+	}}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "console-mce-console", Namespace: "mce"},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+	cl := fake.NewClientBuilder().WithObjects(plugin, endpoints).Build()
+
+	reporter := NewResourceStatus(plugin)
+	if reporter == nil {
+		t.Fatal("expected a reporter for the ConsolePlugin")
+	}
+	if got := reporter.Status(cl); !got.Available || got.Kind != "ConsolePlugin" {
+		t.Errorf("expected the plugin to be available, got %v", got)
+	}
+}
+
+func TestNewResourceStatus(t *testing.T) {
+	tests := []struct {
+		apiVersion string
+		kind       string
+		want       bool
+	}{
+		{apiVersion: "apps/v1", kind: "StatefulSet", want: true},
+		{apiVersion: "apps/v1", kind: "DaemonSet", want: true},
+		{apiVersion: "batch/v1", kind: "Job", want: true},
+		{apiVersion: "apiregistration.k8s.io/v1", kind: "APIService", want: true},
+		{apiVersion: "admissionregistration.k8s.io/v1", kind: "MutatingWebhookConfiguration", want: true},
+		{apiVersion: "console.openshift.io/v1", kind: "ConsolePlugin", want: true},
+		{apiVersion: "apps/v1", kind: "Deployment", want: false},
+		{apiVersion: "v1", kind: "Service", want: false},
+	}
+	for _, tt := range tests {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(tt.apiVersion)
+		u.SetKind(tt.kind)
+		if got := NewResourceStatus(u) != nil; got != tt.want {
+			t.Errorf("NewResourceStatus(%s) returned a reporter = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"fmt"
+
+	bpv1 "github.com/stolostron/backplane-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	statefulSetGVK = appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+	daemonSetGVK   = appsv1.SchemeGroupVersion.WithKind("DaemonSet")
+	jobGVK         = batchv1.SchemeGroupVersion.WithKind("Job")
+)
+
+// StatefulSetStatus fulfills the StatusReporter interface for statefulsets
+type StatefulSetStatus struct {
+	types.NamespacedName
+}
+
+func (s StatefulSetStatus) GetName() string {
+	return s.Name
+}
+
+func (s StatefulSetStatus) GetNamespace() string {
+	return s.Namespace
+}
+
+func (s StatefulSetStatus) GetKind() string {
+	return statefulSetGVK.Kind
+}
+
+// Converts a statefulset's status to a backplane component status. It is available once every replica
+// of the latest revision is ready.
+func (s StatefulSetStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	sts := &appsv1.StatefulSet{}
+	if cc := getResource(k8sClient, s.NamespacedName, statefulSetGVK, sts); cc != nil {
+		return *cc
+	}
+	return withPodFailure(k8sClient, mapStatefulSet(sts), sts.Namespace, sts.Spec.Selector)
+}
+
+func mapStatefulSet(sts *appsv1.StatefulSet) bpv1.ComponentCondition {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	message := fmt.Sprintf("%d of %d replicas ready", sts.Status.ReadyReplicas, replicas)
+
+	switch {
+	case sts.Status.ObservedGeneration < sts.Generation:
+		return availableStatus(sts.Name, statefulSetGVK.Kind, false, "RolloutInProgress", "Waiting for the statefulset spec to be observed")
+	case sts.Status.UpdateRevision != "" && sts.Status.UpdateRevision != sts.Status.CurrentRevision:
+		return availableStatus(sts.Name, statefulSetGVK.Kind, false, "RolloutInProgress",
+			fmt.Sprintf("%d of %d replicas updated", sts.Status.UpdatedReplicas, replicas))
+	case sts.Status.ReadyReplicas < replicas:
+		return availableStatus(sts.Name, statefulSetGVK.Kind, false, "MinimumReplicasUnavailable", message)
+	}
+	return availableStatus(sts.Name, statefulSetGVK.Kind, true, "MinimumReplicasAvailable", "")
+}
+
+// DaemonSetStatus fulfills the StatusReporter interface for daemonsets
+type DaemonSetStatus struct {
+	types.NamespacedName
+}
+
+func (s DaemonSetStatus) GetName() string {
+	return s.Name
+}
+
+func (s DaemonSetStatus) GetNamespace() string {
+	return s.Namespace
+}
+
+func (s DaemonSetStatus) GetKind() string {
+	return daemonSetGVK.Kind
+}
+
+// Converts a daemonset's status to a backplane component status. It is available once the latest revision
+// is available on every node it is scheduled to.
+func (s DaemonSetStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	ds := &appsv1.DaemonSet{}
+	if cc := getResource(k8sClient, s.NamespacedName, daemonSetGVK, ds); cc != nil {
+		return *cc
+	}
+	return withPodFailure(k8sClient, mapDaemonSet(ds), ds.Namespace, ds.Spec.Selector)
+}
+
+func mapDaemonSet(ds *appsv1.DaemonSet) bpv1.ComponentCondition {
+	desired := ds.Status.DesiredNumberScheduled
+	switch {
+	case ds.Status.ObservedGeneration < ds.Generation:
+		return availableStatus(ds.Name, daemonSetGVK.Kind, false, "RolloutInProgress", "Waiting for the daemonset spec to be observed")
+	case ds.Status.UpdatedNumberScheduled < desired:
+		return availableStatus(ds.Name, daemonSetGVK.Kind, false, "RolloutInProgress",
+			fmt.Sprintf("%d of %d scheduled pods updated", ds.Status.UpdatedNumberScheduled, desired))
+	case ds.Status.NumberAvailable < desired:
+		return availableStatus(ds.Name, daemonSetGVK.Kind, false, "MinimumReplicasUnavailable",
+			fmt.Sprintf("%d of %d scheduled pods available", ds.Status.NumberAvailable, desired))
+	}
+	return availableStatus(ds.Name, daemonSetGVK.Kind, true, "MinimumReplicasAvailable", "")
+}
+
+// JobStatus fulfills the StatusReporter interface for jobs
+type JobStatus struct {
+	types.NamespacedName
+}
+
+func (s JobStatus) GetName() string {
+	return s.Name
+}
+
+func (s JobStatus) GetNamespace() string {
+	return s.Namespace
+}
+
+func (s JobStatus) GetKind() string {
+	return jobGVK.Kind
+}
+
+// Converts a job's status to a backplane component status. It is available once the job completes.
+func (s JobStatus) Status(k8sClient client.Client) bpv1.ComponentCondition {
+	job := &batchv1.Job{}
+	if cc := getResource(k8sClient, s.NamespacedName, jobGVK, job); cc != nil {
+		return *cc
+	}
+	return mapJob(job)
+}
+
+func mapJob(job *batchv1.Job) bpv1.ComponentCondition {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return availableStatus(job.Name, jobGVK.Kind, true, "Complete", "")
+		case batchv1.JobFailed:
+			return availableStatus(job.Name, jobGVK.Kind, false, c.Reason, c.Message)
+		}
+	}
+	return availableStatus(job.Name, jobGVK.Kind, false, "Running",
+		fmt.Sprintf("%d active, %d failed pods", job.Status.Active, job.Status.Failed))
+}
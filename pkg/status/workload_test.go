@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_mapStatefulSet(t *testing.T) {
+	one, two := int32(1), int32(2)
+	tests := []struct {
+		name          string
+		sts           *appsv1.StatefulSet
+		wantAvailable bool
+		wantReason    string
+	}{
+		{
+			name: "ready",
+			sts: &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &one},
+				Status: appsv1.StatefulSetStatus{ReadyReplicas: 1, CurrentRevision: "r1", UpdateRevision: "r1"},
+			},
+			wantAvailable: true,
+			wantReason:    "MinimumReplicasAvailable",
+		},
+		{
+			name: "not ready",
+			sts: &appsv1.StatefulSet{
+				Spec:   appsv1.StatefulSetSpec{Replicas: &two},
+				Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
+			},
+			wantReason: "MinimumReplicasUnavailable",
+		},
+		{
+			name: "rolling out",
+			sts: &appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{ReadyReplicas: 1, CurrentRevision: "r1", UpdateRevision: "r2"},
+			},
+			wantReason: "RolloutInProgress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapStatefulSet(tt.sts)
+			if got.Available != tt.wantAvailable || got.Reason != tt.wantReason {
+				t.Errorf("mapStatefulSet() = %v, want available %v reason %s", got, tt.wantAvailable, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_mapDaemonSet(t *testing.T) {
+	tests := []struct {
+		name          string
+		ds            *appsv1.DaemonSet
+		wantAvailable bool
+	}{
+		{
+			name:          "available on every node",
+			ds:            &appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3}},
+			wantAvailable: true,
+		},
+		{
+			name: "unavailable on a node",
+			ds:   &appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2}},
+		},
+		{
+			name: "generation not observed",
+			ds: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DaemonSetStatus{ObservedGeneration: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapDaemonSet(tt.ds); got.Available != tt.wantAvailable {
+				t.Errorf("mapDaemonSet() = %v, want available %v", got, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func Test_mapJob(t *testing.T) {
+	complete := &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}}}
+	if got := mapJob(complete); !got.Available {
+		t.Errorf("expected a complete job to be available, got %v", got)
+	}
+
+	failed := &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+		Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit",
+	}}}}
+	if got := mapJob(failed); got.Available || got.Reason != "BackoffLimitExceeded" {
+		t.Errorf("expected a failed job to be unavailable with its reason, got %v", got)
+	}
+
+	running := &batchv1.Job{Status: batchv1.JobStatus{Active: 1}}
+	if got := mapJob(running); got.Available || got.Reason != "Running" {
+		t.Errorf("expected a running job to be unavailable, got %v", got)
+	}
+}
+
+func TestStatefulSetStatus(t *testing.T) {
+	labels := map[string]string{"app": "postgres"}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "test"},
+		Spec:       appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres-0", Namespace: "test", Labels: labels},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "postgres",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}},
+	}
+	cl := fake.NewClientBuilder().WithObjects(sts, pod).Build()
+
+	got := StatefulSetStatus{NamespacedName: types.NamespacedName{Name: "postgres", Namespace: "test"}}.Status(cl)
+	if got.Available || got.Reason != "CrashLoopBackOff" {
+		t.Errorf("expected the pod failure of an unready statefulset, got %v", got)
+	}
+
+	got = StatefulSetStatus{NamespacedName: types.NamespacedName{Name: "missing", Namespace: "test"}}.Status(cl)
+	if got.Available || got.Reason != DeployFailedReason {
+		t.Errorf("expected a missing statefulset to be reported, got %v", got)
+	}
+}
@@ -34,6 +34,20 @@ const (
 	ClusterProxyAddonDir     = "pkg/templates/charts/toggle/cluster-proxy-addon"
 )
 
+// ComponentCharts maps each component deployed from a chart to the chart's directory
+var ComponentCharts = map[string]string{
+	bpv1.AssistedService:       AssistedServiceChartDir,
+	bpv1.ClusterLifecycle:      ClusterLifecycleChartDir,
+	bpv1.ClusterManager:        ClusterManagerChartDir,
+	bpv1.ClusterProxyAddon:     ClusterProxyAddonDir,
+	bpv1.ConsoleMCE:            ConsoleMCEChartsDir,
+	bpv1.Discovery:             DiscoveryChartDir,
+	bpv1.Hive:                  HiveChartDir,
+	bpv1.HyperShift:            HyperShiftChartDir,
+	bpv1.ManagedServiceAccount: ManagedServiceAccountChartDir,
+	bpv1.ServerFoundation:      ServerFoundationChartDir,
+}
+
 func EnabledStatus(namespacedName types.NamespacedName) status.StatusReporter {
 	return status.DeploymentStatus{
 		NamespacedName: namespacedName,
@@ -23,6 +23,15 @@ var (
 	AnnotationImageRepo = "imageRepository"
 	// AnnotationImageOverridesCM identifies a configmap name containing an image override mapping
 	AnnotationImageOverridesCM = "imageOverridesCM"
+	// AnnotationResolveImageMirrors indicates images should be resolved to the mirrors configured in
+	// ImageDigestMirrorSets and ImageContentSourcePolicies when set to true
+	AnnotationResolveImageMirrors = "resolveImageMirrors"
+	// AnnotationRequireImageDigests indicates components should only be deployed with images pinned
+	// by sha256 digest when set to true
+	AnnotationRequireImageDigests = "requireImageDigests"
+	// AnnotationConfigHash is set on the pod template of every rendered Deployment. It changes when
+	// config consumed by pods changes, so that the Deployment rolls out.
+	AnnotationConfigHash = "multiclusterengine.openshift.io/config-hash"
 
 	// AnnotationKubeconfig is the secret name residing in targetcontaining the kubeconfig to access the remote cluster
 	AnnotationKubeconfig = "mce-kubeconfig"
@@ -59,7 +68,8 @@ func ShouldIgnoreOCPVersion(instance *backplanev1.MultiClusterEngine) bool {
 // AnnotationsMatch returns true if all annotation values used by the operator match
 func AnnotationsMatch(old, new map[string]string) bool {
 	return old[AnnotationMCEPause] == new[AnnotationMCEPause] &&
-		old[AnnotationImageRepo] == new[AnnotationImageRepo]
+		old[AnnotationImageRepo] == new[AnnotationImageRepo] &&
+		old[AnnotationResolveImageMirrors] == new[AnnotationResolveImageMirrors]
 }
 
 // AnnotationPresent returns true if annotation is present on object
@@ -93,6 +103,18 @@ func OverrideImageRepository(imageOverrides map[string]string, imageRepo string)
 	return imageOverrides
 }
 
+// ShouldResolveImageMirrors returns true if the multiclusterengine instance is annotated to resolve
+// images to their cluster mirrors
+func ShouldResolveImageMirrors(instance *backplanev1.MultiClusterEngine) bool {
+	return strings.EqualFold(getAnnotation(instance, AnnotationResolveImageMirrors), "true")
+}
+
+// ShouldRequireImageDigests returns true if the multiclusterengine instance is annotated to reject
+// images referenced by tag
+func ShouldRequireImageDigests(instance *backplanev1.MultiClusterEngine) bool {
+	return strings.EqualFold(getAnnotation(instance, AnnotationRequireImageDigests), "true")
+}
+
 // GetImageOverridesConfigmap returns the images override configmap annotation, or an empty string if not set
 func GetImageOverridesConfigmap(instance *backplanev1.MultiClusterEngine) string {
 	return getAnnotation(instance, AnnotationImageOverridesCM)
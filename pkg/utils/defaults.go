@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/platform"
+)
+
+// SetDefaults applies the defaults the operator sets on a MultiClusterEngine running on a cluster of
+// the given platform and version. The console is enabled unless configured otherwise on OpenShift
+// 4.10 and later, where dynamic plugins are supported, and disabled everywhere else. Returns true if
+// changes are made.
+func SetDefaults(m *backplanev1.MultiClusterEngine, platformName, clusterVersion string) (bool, error) {
+	updated := false
+	if !AvailabilityConfigIsValid(m.Spec.AvailabilityConfig) {
+		m.Spec.AvailabilityConfig = backplanev1.HAHigh
+		updated = true
+	}
+	if m.Spec.TargetNamespace == "" {
+		m.Spec.TargetNamespace = backplanev1.DefaultTargetNamespace
+		updated = true
+	}
+	if SetDefaultComponents(m) {
+		updated = true
+	}
+	// hyper-shift preview component upgraded in 2.8.0
+	if m.Prune(backplanev1.HyperShiftPreview) {
+		updated = true
+	}
+	if DeduplicateComponents(m) {
+		updated = true
+	}
+
+	consoleSupported := false
+	if platformName == platform.OpenShift {
+		version, err := semver.NewVersion(strings.TrimPrefix(clusterVersion, "v"))
+		if err != nil {
+			return false, fmt.Errorf("invalid OCP version %q: %w", clusterVersion, err)
+		}
+		// -0 allows for prerelease builds to pass the validation.
+		// If -0 is removed, developer/rc builds will not pass this check
+		constraint, err := semver.NewConstraint(">= 4.10.0-0")
+		if err != nil {
+			return false, err
+		}
+		consoleSupported = constraint.Check(version)
+	}
+	if consoleSupported {
+		// If ConsoleMCE config already exists, then don't overwrite it
+		if !m.ComponentPresent(backplanev1.ConsoleMCE) {
+			m.Enable(backplanev1.ConsoleMCE)
+			updated = true
+		}
+	} else if m.Enabled(backplanev1.ConsoleMCE) {
+		m.Disable(backplanev1.ConsoleMCE)
+		updated = true
+	}
+	return updated, nil
+}
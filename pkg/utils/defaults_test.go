@@ -0,0 +1,76 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package utils
+
+import (
+	"testing"
+
+	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/platform"
+)
+
+func TestSetDefaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		platform    string
+		version     string
+		components  []backplanev1.ComponentConfig
+		wantConsole bool
+		wantErr     bool
+	}{
+		{
+			name:        "OpenShift with dynamic plugins",
+			platform:    platform.OpenShift,
+			version:     "4.14.0",
+			wantConsole: true,
+		},
+		{
+			name:     "OpenShift without dynamic plugins",
+			platform: platform.OpenShift,
+			version:  "4.9.0",
+		},
+		{
+			name:       "console disabled by the user",
+			platform:   platform.OpenShift,
+			version:    "4.14.0",
+			components: []backplanev1.ComponentConfig{{Name: backplanev1.ConsoleMCE, Enabled: false}},
+		},
+		{
+			name:       "Kubernetes",
+			platform:   platform.Kubernetes,
+			components: []backplanev1.ComponentConfig{{Name: backplanev1.ConsoleMCE, Enabled: true}},
+		},
+		{
+			name:     "invalid OCP version",
+			platform: platform.OpenShift,
+			version:  "latest",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &backplanev1.MultiClusterEngine{}
+			m.Spec.Overrides = &backplanev1.Overrides{Components: tt.components}
+			updated, err := SetDefaults(m, tt.platform, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetDefaults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !updated {
+				t.Error("expected defaults to be set")
+			}
+			if m.Spec.TargetNamespace != backplanev1.DefaultTargetNamespace || m.Spec.AvailabilityConfig != backplanev1.HAHigh {
+				t.Errorf("unexpected spec defaults %+v", m.Spec)
+			}
+			if got := m.Enabled(backplanev1.ConsoleMCE); got != tt.wantConsole {
+				t.Errorf("console enabled = %v, want %v", got, tt.wantConsole)
+			}
+
+			if updated, _ := SetDefaults(m, tt.platform, tt.version); updated {
+				t.Error("expected no changes once defaults are set")
+			}
+		})
+	}
+}
@@ -3,18 +3,31 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 
+	configv1 "github.com/openshift/api/config/v1"
 	backplanev1 "github.com/stolostron/backplane-operator/api/v1"
+	"github.com/stolostron/backplane-operator/pkg/platform"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	UnitTestEnvVar = "UNIT_TEST"
+
+	// ClusterHTTPProxyEnvVar holds the HTTP proxy of the cluster-wide Proxy config
+	ClusterHTTPProxyEnvVar = "ACM_CLUSTER_HTTP_PROXY"
+	// ClusterHTTPSProxyEnvVar holds the HTTPS proxy of the cluster-wide Proxy config
+	ClusterHTTPSProxyEnvVar = "ACM_CLUSTER_HTTPS_PROXY"
+	// ClusterNoProxyEnvVar holds the no proxy list of the cluster-wide Proxy config
+	ClusterNoProxyEnvVar = "ACM_CLUSTER_NO_PROXY"
 )
 
 var onComponents = []string{
@@ -105,6 +118,44 @@ func ProxyEnvVarsAreSet() bool {
 	return false
 }
 
+// ProxyConfigs returns the effective proxy settings for components. The operator's proxy env vars,
+// set by OLM from the Subscription, take precedence over the cluster-wide Proxy config. Returns nil
+// when no proxy is configured.
+func ProxyConfigs() map[string]string {
+	if ProxyEnvVarsAreSet() {
+		return map[string]string{
+			"HTTP_PROXY":  os.Getenv("HTTP_PROXY"),
+			"HTTPS_PROXY": os.Getenv("HTTPS_PROXY"),
+			"NO_PROXY":    os.Getenv("NO_PROXY"),
+		}
+	}
+	if os.Getenv(ClusterHTTPProxyEnvVar) != "" || os.Getenv(ClusterHTTPSProxyEnvVar) != "" || os.Getenv(ClusterNoProxyEnvVar) != "" {
+		return map[string]string{
+			"HTTP_PROXY":  os.Getenv(ClusterHTTPProxyEnvVar),
+			"HTTPS_PROXY": os.Getenv(ClusterHTTPSProxyEnvVar),
+			"NO_PROXY":    os.Getenv(ClusterNoProxyEnvVar),
+		}
+	}
+	return nil
+}
+
+// SetClusterProxy stores the cluster-wide Proxy config in env vars, so that charts can render it when
+// the operator has no proxy env vars of its own. Only OpenShift has a cluster-wide Proxy config.
+func SetClusterProxy(ctx context.Context, c client.Client, platformName string) error {
+	proxy := &configv1.Proxy{}
+	if platformName == platform.OpenShift {
+		err := c.Get(ctx, types.NamespacedName{Name: "cluster"}, proxy)
+		if err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+			return err
+		}
+	}
+
+	os.Setenv(ClusterHTTPProxyEnvVar, proxy.Status.HTTPProxy)
+	os.Setenv(ClusterHTTPSProxyEnvVar, proxy.Status.HTTPSProxy)
+	os.Setenv(ClusterNoProxyEnvVar, proxy.Status.NoProxy)
+	return nil
+}
+
 func DefaultReplicaCount(mce *backplanev1.MultiClusterEngine) int {
 	if mce.Spec.AvailabilityConfig == backplanev1.HABasic {
 		return 1
@@ -170,7 +221,8 @@ func GetTestImages() []string {
 		"assisted_service", "assisted_image_service", "postgresql_12", "assisted_installer_agent", "assisted_installer_controller",
 		"assisted_installer", "console_mce", "hypershift_addon_operator", "hypershift_operator",
 		"apiserver_network_proxy", "aws_encryption_provider", "cluster_api", "cluster_api_provider_agent", "cluster_api_provider_aws",
-		"cluster_api_provider_azure", "cluster_api_provider_kubevirt", "kube_rbac_proxy_mce", "cluster_proxy_addon", "cluster_proxy", "cluster_image_set_controller"}
+		"cluster_api_provider_azure", "cluster_api_provider_kubevirt", "kube_rbac_proxy_mce", "cluster_proxy_addon", "cluster_proxy", "cluster_image_set_controller",
+		"placement", "addon_manager"}
 }
 
 func IsUnitTest() bool {
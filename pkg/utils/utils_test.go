@@ -156,3 +156,61 @@ func TestGetHubType(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		cluster map[string]string
+		want    map[string]string
+	}{
+		{
+			name: "no proxy",
+			want: nil,
+		},
+		{
+			name: "cluster proxy",
+			cluster: map[string]string{
+				ClusterHTTPProxyEnvVar:  "http://cluster:3128",
+				ClusterHTTPSProxyEnvVar: "http://cluster:3128",
+				ClusterNoProxyEnvVar:    ".cluster.local",
+			},
+			want: map[string]string{
+				"HTTP_PROXY":  "http://cluster:3128",
+				"HTTPS_PROXY": "http://cluster:3128",
+				"NO_PROXY":    ".cluster.local",
+			},
+		},
+		{
+			name: "operator env vars take precedence",
+			env: map[string]string{
+				"HTTP_PROXY": "http://subscription:3128",
+			},
+			cluster: map[string]string{
+				ClusterHTTPProxyEnvVar: "http://cluster:3128",
+				ClusterNoProxyEnvVar:   ".cluster.local",
+			},
+			want: map[string]string{
+				"HTTP_PROXY":  "http://subscription:3128",
+				"HTTPS_PROXY": "",
+				"NO_PROXY":    "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", ClusterHTTPProxyEnvVar, ClusterHTTPSProxyEnvVar, ClusterNoProxyEnvVar} {
+				t.Setenv(k, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			for k, v := range tt.cluster {
+				t.Setenv(k, v)
+			}
+			if got := ProxyConfigs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProxyConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}